@@ -0,0 +1,151 @@
+// Package apiclient is a thin client of Gcore JSON REST APIs, which have no
+// SDK, with the shared response cache and error helpers
+package apiclient
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/G-core/gcore-cli/internal/config"
+	e "github.com/G-core/gcore-cli/internal/errors"
+	"github.com/G-core/gcore-cli/internal/httpcache"
+	"github.com/G-core/gcore-cli/internal/suggest"
+)
+
+// AuthFunc adds credentials to the request
+type AuthFunc func(ctx context.Context, req *http.Request) error
+
+// Client sends JSON requests to the API at BaseUrl
+type Client struct {
+	BaseUrl string
+	Auth    AuthFunc
+	Doer    httpcache.Doer
+	// ParseErr converts error response body into CLI error, it returns nil
+	// if the body has no error message
+	ParseErr func(body []byte) *e.CliError
+}
+
+// Do sends the request with in marshaled as JSON body and decodes response
+// into out, if it is not nil. Raw response body is returned for JSON output.
+// Error responses are converted into CLI errors with the API message.
+func (c *Client) Do(ctx context.Context, method, path string, query url.Values, in any, out any) ([]byte, error) {
+	u := c.BaseUrl + path
+	if len(query) > 0 {
+		u += "?" + query.Encode()
+	}
+
+	var body io.Reader
+	if in != nil {
+		buf, err := json.Marshal(in)
+		if err != nil {
+			return nil, err
+		}
+		body = bytes.NewReader(buf)
+	}
+	req, err := http.NewRequestWithContext(ctx, method, u, body)
+	if err != nil {
+		return nil, err
+	}
+	if in != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	req.Header.Set("User-Agent", "gcore-cli")
+	if err := c.Auth(ctx, req); err != nil {
+		return nil, err
+	}
+
+	rsp, err := c.Doer.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer rsp.Body.Close()
+	buf, err := io.ReadAll(rsp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	if rsp.StatusCode >= http.StatusBadRequest {
+		if c.ParseErr != nil {
+			if cliErr := c.ParseErr(buf); cliErr != nil && cliErr.Err != nil && cliErr.Err.Error() != "" {
+				return nil, cliErr
+			}
+		}
+		return nil, fmt.Errorf("%s %s: %s", method, path, rsp.Status)
+	}
+	if out != nil && len(buf) > 0 {
+		if err := json.Unmarshal(buf, out); err != nil {
+			return nil, fmt.Errorf("cannot parse API response: %w", err)
+		}
+	}
+	return buf, nil
+}
+
+func (c *Client) Get(path string, query url.Values, out any) ([]byte, error) {
+	return c.Do(context.Background(), http.MethodGet, path, query, nil, out)
+}
+
+func (c *Client) Post(path string, in any, out any) ([]byte, error) {
+	return c.Do(context.Background(), http.MethodPost, path, nil, in, out)
+}
+
+func (c *Client) Patch(path string, in any, out any) ([]byte, error) {
+	return c.Do(context.Background(), http.MethodPatch, path, nil, in, out)
+}
+
+func (c *Client) Delete(path string, query url.Values, out any) ([]byte, error) {
+	return c.Do(context.Background(), http.MethodDelete, path, query, nil, out)
+}
+
+// Identity returns string, identifying API URL and credentials, so cached
+// and saved data of different accounts don't mix
+func Identity(baseUrl string, auth AuthFunc) string {
+	req, _ := http.NewRequest(http.MethodGet, baseUrl, nil)
+	auth(context.Background(), req)
+	return baseUrl + "\n" + req.Header.Get("Authorization")
+}
+
+// CachedDoer wraps doer into the response cache, configured by "--cache-ttl"
+// and "--no-cache" flags. It is done even with caching disabled, so mutating
+// requests still invalidate the cache.
+func CachedDoer(cmd *cobra.Command, doer httpcache.Doer, identity string) (*httpcache.Client, error) {
+	ttl, err := cmd.Flags().GetDuration("cache-ttl")
+	if err != nil {
+		return nil, err
+	}
+	noCache, err := cmd.Flags().GetBool("no-cache")
+	if err != nil {
+		return nil, err
+	}
+	dir, err := config.Dir("cache", "http")
+	if err != nil {
+		return nil, err
+	}
+	return &httpcache.Client{
+		Doer:     doer,
+		Dir:      dir,
+		TTL:      ttl,
+		Identity: identity,
+		Refresh:  noCache,
+	}, nil
+}
+
+// NotFound returns error for the resource of the kind, that is not found by
+// name, suggesting similar names
+func NotFound(kind, arg string, names []string) error {
+	cliErr := &e.CliError{
+		Err:  fmt.Errorf("%s '%s' not found", kind, arg),
+		Code: 1,
+	}
+	if similar := suggest.Closest(arg, names, 3); len(similar) > 0 {
+		cliErr.Hint = "Did you mean '" + strings.Join(similar, "', '") + "'?"
+	}
+	return cliErr
+}
@@ -0,0 +1,105 @@
+package cdn
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/G-core/gcore-cli/internal/apiclient"
+	e "github.com/G-core/gcore-cli/internal/errors"
+)
+
+var client *apiclient.Client
+
+// top-level CDN command
+func Commands(baseUrl string, authFunc func(ctx context.Context, req *http.Request) error) (*cobra.Command, error) {
+	var cmdCdn = &cobra.Command{
+		Use:   "cdn <subcommand>",
+		Short: "Gcore CDN resources management",
+		Long: `Gcore CDN resources management. CDN resources can be specified by ID or
+by their CNAME (or secondary hostname), origin groups by ID or name.`,
+		Args: cobra.MinimumNArgs(1),
+		PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+			doer, err := apiclient.CachedDoer(cmd, http.DefaultClient, apiclient.Identity(baseUrl, authFunc))
+			if err != nil {
+				return err
+			}
+			client = &apiclient.Client{
+				BaseUrl:  baseUrl + "/cdn",
+				Auth:     authFunc,
+				Doer:     doer,
+				ParseErr: e.ParseCdnErr,
+			}
+			return nil
+		},
+	}
+
+	cmdCdn.AddCommand(
+		resource(),
+		originGroup(),
+		rule(),
+		certificate(),
+		purge(),
+		prefetch(),
+	)
+	return cmdCdn, nil
+}
+
+// resourceId returns ID of the CDN resource, specified by ID, CNAME or
+// secondary hostname
+func resourceId(arg string) (int, error) {
+	if id, err := strconv.Atoi(arg); err == nil {
+		return id, nil
+	}
+
+	var resources []resourceInfo
+	if _, err := client.Get("/resources", nil, &resources); err != nil {
+		return 0, fmt.Errorf("getting the list of CDN resources: %w", err)
+	}
+	var names []string
+	for _, r := range resources {
+		if strings.EqualFold(r.Cname, arg) || slices.ContainsFunc(r.SecondaryHostnames, func(h string) bool {
+			return strings.EqualFold(h, arg)
+		}) {
+			return r.Id, nil
+		}
+		names = append(names, r.Cname)
+	}
+	return 0, apiclient.NotFound("CDN resource", arg, names)
+}
+
+// originGroupId returns ID of the origin group, specified by ID or name
+func originGroupId(arg string) (int, error) {
+	if id, err := strconv.Atoi(arg); err == nil {
+		return id, nil
+	}
+
+	var groups []originGroupInfo
+	if _, err := client.Get("/origin_groups", nil, &groups); err != nil {
+		return 0, fmt.Errorf("getting the list of origin groups: %w", err)
+	}
+	var ids []int
+	var names []string
+	for _, g := range groups {
+		if g.Name == arg {
+			ids = append(ids, g.Id)
+		}
+		names = append(names, g.Name)
+	}
+	switch len(ids) {
+	case 1:
+		return ids[0], nil
+	case 0:
+		return 0, apiclient.NotFound("origin group", arg, names)
+	}
+	return 0, &e.CliError{
+		Err:  fmt.Errorf("origin group name '%s' is ambiguous, it matches %d groups", arg, len(ids)),
+		Hint: "Specify origin group by ID",
+		Code: 1,
+	}
+}
@@ -0,0 +1,90 @@
+package cdn
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/alecthomas/assert"
+
+	"github.com/G-core/gcore-cli/internal/apiclient"
+	e "github.com/G-core/gcore-cli/internal/errors"
+)
+
+func testClient(t *testing.T, handler http.HandlerFunc) {
+	srv := httptest.NewServer(handler)
+	t.Cleanup(srv.Close)
+	client = &apiclient.Client{
+		BaseUrl: srv.URL,
+		Auth: func(ctx context.Context, req *http.Request) error {
+			req.Header.Set("Authorization", "APIKey test")
+			return nil
+		},
+		Doer:     http.DefaultClient,
+		ParseErr: e.ParseCdnErr,
+	}
+}
+
+func TestClientError(t *testing.T) {
+	testClient(t, func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/field":
+			w.WriteHeader(http.StatusBadRequest)
+			io.WriteString(w, `{"errors":{"cname":["This field is required."]}}`)
+		case "/fields":
+			w.WriteHeader(http.StatusBadRequest)
+			io.WriteString(w, `{"errors":{"origin":["Invalid host."],"cname":["Already exists."]}}`)
+		case "/message":
+			w.WriteHeader(http.StatusNotFound)
+			io.WriteString(w, `{"message":"Not found."}`)
+		default:
+			w.WriteHeader(http.StatusBadGateway)
+			io.WriteString(w, `<html>bad gateway</html>`)
+		}
+	})
+
+	_, err := client.Get("/field", nil, nil)
+	assert.EqualError(t, err, "cname: This field is required.")
+
+	_, err = client.Get("/fields", nil, nil)
+	var cliErr *e.CliError
+	assert.True(t, errors.As(err, &cliErr))
+	assert.Equal(t, "request is invalid", cliErr.Error())
+	assert.Equal(t, "cname: Already exists.\norigin: Invalid host.", cliErr.Details)
+
+	_, err = client.Get("/message", nil, nil)
+	assert.EqualError(t, err, "Not found.")
+
+	_, err = client.Get("/html", nil, nil)
+	assert.EqualError(t, err, "GET /html: 502 Bad Gateway")
+}
+
+func TestResourceId(t *testing.T) {
+	testClient(t, func(w http.ResponseWriter, r *http.Request) {
+		io.WriteString(w, `[
+			{"id":1,"cname":"cdn.example.com","secondaryHostnames":["static.example.com"]},
+			{"id":2,"cname":"img.example.com","secondaryHostnames":[]}
+		]`)
+	})
+
+	id, err := resourceId("42")
+	assert.NoError(t, err)
+	assert.Equal(t, 42, id)
+
+	id, err = resourceId("img.example.com")
+	assert.NoError(t, err)
+	assert.Equal(t, 2, id)
+
+	id, err = resourceId("Static.Example.com")
+	assert.NoError(t, err)
+	assert.Equal(t, 1, id)
+
+	_, err = resourceId("img.example.org")
+	var cliErr *e.CliError
+	assert.True(t, errors.As(err, &cliErr))
+	assert.Equal(t, "CDN resource 'img.example.org' not found", cliErr.Error())
+	assert.Equal(t, "Did you mean 'img.example.com', 'cdn.example.com'?", cliErr.Hint)
+}
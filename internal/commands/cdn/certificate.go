@@ -0,0 +1,304 @@
+package cdn
+
+import (
+	"crypto"
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/G-core/gcore-cli/internal/apiclient"
+	e "github.com/G-core/gcore-cli/internal/errors"
+	"github.com/G-core/gcore-cli/internal/output"
+	"github.com/G-core/gcore-cli/internal/sure"
+)
+
+type certificateInfo struct {
+	Id                  int    `json:"id"`
+	Name                string `json:"name"`
+	CertIssuer          string `json:"cert_issuer"`
+	CertSubjectCn       string `json:"cert_subject_cn"`
+	ValidityNotBefore   string `json:"validity_not_before"`
+	ValidityNotAfter    string `json:"validity_not_after"`
+	HasRelatedResources bool   `json:"hasRelatedResources"`
+	Automated           bool   `json:"automated"`
+}
+
+func certificate() *cobra.Command {
+	var cmdCertificate = &cobra.Command{
+		Use:     "certificate <subcommand>",
+		Aliases: []string{"certificates", "cert"},
+		Short:   "SSL certificates management",
+		Long: `SSL certificates management. Certificates can be specified by ID or name,
+and are used by CDN resources with "cdn resource update --certificate".`,
+		Args: cobra.MinimumNArgs(1),
+	}
+
+	var cmdList = &cobra.Command{
+		Use:     "list",
+		Aliases: []string{"ls"},
+		Short:   "Show list of certificates",
+		Args:    cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			var certs []certificateInfo
+			body, err := client.Get("/sslData", nil, &certs)
+			if err != nil {
+				return fmt.Errorf("getting the list of certificates: %w", err)
+			}
+
+			if output.Format(cmd) == output.FmtJSON {
+				fmt.Println(string(body))
+				return nil
+			}
+
+			if len(certs) == 0 {
+				fmt.Printf("you have no certificates\n")
+				return nil
+			}
+
+			table := make([][]string, len(certs)+1)
+			table[0] = []string{"ID", "Name", "Subject", "Issuer", "Expires", "In use"}
+			for i, c := range certs {
+				table[i+1] = []string{
+					strconv.Itoa(c.Id),
+					c.Name,
+					c.CertSubjectCn,
+					c.CertIssuer,
+					c.ValidityNotAfter,
+					strconv.FormatBool(c.HasRelatedResources),
+				}
+			}
+			output.Table(table, output.Format(cmd))
+			return nil
+		},
+	}
+
+	var cmdUpload = &cobra.Command{
+		Use:   "upload <name>",
+		Short: "Upload the certificate",
+		Long: `Upload the certificate with its private key. Certificate file contains the
+certificate, optionally followed by intermediate certificates of the chain.
+Before upload, the chain and the key are checked to match, and validity
+dates are shown. Expired certificates are not uploaded.`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			certFile, err := cmd.Flags().GetString("cert")
+			if err != nil {
+				return err
+			}
+			keyFile, err := cmd.Flags().GetString("key")
+			if err != nil {
+				return err
+			}
+			certPem, err := os.ReadFile(certFile)
+			if err != nil {
+				return fmt.Errorf("cannot read certificate: %w", err)
+			}
+			keyPem, err := os.ReadFile(keyFile)
+			if err != nil {
+				return fmt.Errorf("cannot read private key: %w", err)
+			}
+
+			chain, err := parseCertificate(certPem, keyPem)
+			if err != nil {
+				return &e.CliError{
+					Err:  err,
+					Hint: "Check that certificate and key files are in PEM format and belong together",
+					Code: 1,
+				}
+			}
+			leaf := chain[0]
+			if output.Format(cmd) != output.FmtJSON {
+				fmt.Printf("Subject:  %s\n", leaf.Subject.CommonName)
+				if len(leaf.DNSNames) > 0 {
+					fmt.Printf("Names:    %s\n", strings.Join(leaf.DNSNames, ", "))
+				}
+				fmt.Printf("Issuer:   %s\n", leaf.Issuer.CommonName)
+				fmt.Printf("Valid:    %s - %s (%s)\n",
+					leaf.NotBefore.Format(time.DateOnly),
+					leaf.NotAfter.Format(time.DateOnly),
+					expiresIn(leaf.NotAfter, time.Now()),
+				)
+			}
+			if err := checkValidity(chain, time.Now()); err != nil {
+				return &e.CliError{Err: err, Code: 1}
+			}
+
+			req := map[string]string{
+				"name":           args[0],
+				"sslCertificate": string(certPem),
+				"sslPrivateKey":  string(keyPem),
+			}
+			var cert certificateInfo
+			body, err := client.Post("/sslData", req, &cert)
+			if err != nil {
+				return fmt.Errorf("uploading the certificate: %w", err)
+			}
+
+			if output.Format(cmd) == output.FmtJSON {
+				fmt.Println(string(body))
+				return nil
+			}
+			fmt.Printf("Certificate %d uploaded\n", cert.Id)
+			return nil
+		},
+	}
+	cmdUpload.Flags().String("cert", "", "Certificate chain file in PEM format")
+	cmdUpload.Flags().String("key", "", "Private key file in PEM format")
+	cmdUpload.MarkFlagRequired("cert")
+	cmdUpload.MarkFlagRequired("key")
+	cmdUpload.MarkFlagFilename("cert", "pem", "crt", "cer")
+	cmdUpload.MarkFlagFilename("key", "pem", "key")
+
+	var cmdDelete = &cobra.Command{
+		Use:     "delete <certificate>",
+		Aliases: []string{"rm"},
+		Short:   "Delete the certificate",
+		Args:    cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			id, err := certificateId(args[0])
+			if err != nil {
+				return err
+			}
+			if !sure.AreYou(cmd, fmt.Sprintf("delete certificate %d", id)) {
+				return e.ErrAborted
+			}
+
+			if _, err := client.Delete("/sslData/"+strconv.Itoa(id), nil, nil); err != nil {
+				return fmt.Errorf("deleting the certificate: %w", err)
+			}
+			fmt.Printf("Certificate %d deleted\n", id)
+			return nil
+		},
+	}
+
+	cmdCertificate.AddCommand(cmdList, cmdUpload, cmdDelete)
+	return cmdCertificate
+}
+
+// certificateId returns ID of the certificate, specified by ID or name
+func certificateId(arg string) (int, error) {
+	if id, err := strconv.Atoi(arg); err == nil {
+		return id, nil
+	}
+
+	var certs []certificateInfo
+	if _, err := client.Get("/sslData", nil, &certs); err != nil {
+		return 0, fmt.Errorf("getting the list of certificates: %w", err)
+	}
+	var names []string
+	for _, c := range certs {
+		if c.Name == arg {
+			return c.Id, nil
+		}
+		names = append(names, c.Name)
+	}
+	return 0, apiclient.NotFound("certificate", arg, names)
+}
+
+// checkValidity checks that every certificate in the chain is valid at the
+// given time, as expired intermediate breaks the chain as well as the leaf
+func checkValidity(chain []*x509.Certificate, now time.Time) error {
+	for _, cert := range chain {
+		if now.Before(cert.NotBefore) {
+			return fmt.Errorf("certificate '%s' is not valid until %s", cert.Subject.CommonName, cert.NotBefore.Format(time.DateOnly))
+		}
+		if now.After(cert.NotAfter) {
+			return fmt.Errorf("certificate '%s' expired on %s", cert.Subject.CommonName, cert.NotAfter.Format(time.DateOnly))
+		}
+	}
+	return nil
+}
+
+// parseCertificate returns certificates of the chain, checking that each
+// of them is signed by the next one and the key matches the first one
+func parseCertificate(certPem, keyPem []byte) ([]*x509.Certificate, error) {
+	var chain []*x509.Certificate
+	for rest := certPem; ; {
+		var block *pem.Block
+		block, rest = pem.Decode(rest)
+		if block == nil {
+			break
+		}
+		if block.Type != "CERTIFICATE" {
+			continue
+		}
+		cert, err := x509.ParseCertificate(block.Bytes)
+		if err != nil {
+			return nil, fmt.Errorf("cannot parse certificate %d of the chain: %w", len(chain)+1, err)
+		}
+		chain = append(chain, cert)
+	}
+	if len(chain) == 0 {
+		return nil, errors.New("no certificates found in PEM data")
+	}
+	for i := 0; i < len(chain)-1; i++ {
+		if err := chain[i].CheckSignatureFrom(chain[i+1]); err != nil {
+			return nil, fmt.Errorf("certificate '%s' is not signed by the next one in the chain, '%s'",
+				chain[i].Subject.CommonName, chain[i+1].Subject.CommonName)
+		}
+	}
+
+	key, err := parsePrivateKey(keyPem)
+	if err != nil {
+		return nil, err
+	}
+	pub, ok := key.Public().(interface{ Equal(crypto.PublicKey) bool })
+	if !ok || !pub.Equal(chain[0].PublicKey) {
+		return nil, errors.New("private key does not match the certificate")
+	}
+	return chain, nil
+}
+
+func parsePrivateKey(keyPem []byte) (crypto.Signer, error) {
+	for rest := keyPem; ; {
+		var block *pem.Block
+		block, rest = pem.Decode(rest)
+		if block == nil {
+			return nil, errors.New("no private key found in PEM data")
+		}
+
+		var key any
+		var err error
+		switch block.Type {
+		case "PRIVATE KEY":
+			key, err = x509.ParsePKCS8PrivateKey(block.Bytes)
+		case "RSA PRIVATE KEY":
+			key, err = x509.ParsePKCS1PrivateKey(block.Bytes)
+		case "EC PRIVATE KEY":
+			key, err = x509.ParseECPrivateKey(block.Bytes)
+		case "ENCRYPTED PRIVATE KEY":
+			return nil, errors.New("private key is encrypted, decrypt it first")
+		default:
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("cannot parse private key: %w", err)
+		}
+		signer, ok := key.(crypto.Signer)
+		if !ok {
+			return nil, errors.New("unsupported private key type")
+		}
+		return signer, nil
+	}
+}
+
+// expiresIn describes certificate expiration relative to now
+func expiresIn(notAfter, now time.Time) string {
+	days := int(notAfter.Sub(now).Hours() / 24)
+	switch {
+	case notAfter.Before(now):
+		return "expired"
+	case days == 0:
+		return "expires today"
+	case days == 1:
+		return "expires in 1 day"
+	}
+	return fmt.Sprintf("expires in %d days", days)
+}
@@ -0,0 +1,91 @@
+package cdn
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/alecthomas/assert"
+)
+
+func testCert(t *testing.T, cn string, parent *x509.Certificate, parentKey *ecdsa.PrivateKey) (*x509.Certificate, *ecdsa.PrivateKey, []byte) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	assert.NoError(t, err)
+	tmpl := &x509.Certificate{
+		SerialNumber:          big.NewInt(time.Now().UnixNano()),
+		Subject:               pkix.Name{CommonName: cn},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(24 * time.Hour),
+		IsCA:                  parent == nil,
+		BasicConstraintsValid: true,
+		KeyUsage:              x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign,
+	}
+	if parent == nil {
+		parent, parentKey = tmpl, key
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, parent, &key.PublicKey, parentKey)
+	assert.NoError(t, err)
+	cert, err := x509.ParseCertificate(der)
+	assert.NoError(t, err)
+	return cert, key, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})
+}
+
+func keyPem(t *testing.T, key *ecdsa.PrivateKey) []byte {
+	der, err := x509.MarshalPKCS8PrivateKey(key)
+	assert.NoError(t, err)
+	return pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der})
+}
+
+func TestParseCertificate(t *testing.T) {
+	ca, caKey, caPem := testCert(t, "Test CA", nil, nil)
+	_, leafKey, leafPem := testCert(t, "cdn.example.com", ca, caKey)
+	_, _, otherPem := testCert(t, "Other CA", nil, nil)
+
+	chain, err := parseCertificate(append(leafPem, caPem...), keyPem(t, leafKey))
+	assert.NoError(t, err)
+	assert.Equal(t, 2, len(chain))
+	assert.Equal(t, "cdn.example.com", chain[0].Subject.CommonName)
+
+	_, err = parseCertificate(leafPem, keyPem(t, caKey))
+	assert.EqualError(t, err, "private key does not match the certificate")
+
+	_, err = parseCertificate(append(leafPem, otherPem...), keyPem(t, leafKey))
+	assert.EqualError(t, err, "certificate 'cdn.example.com' is not signed by the next one in the chain, 'Other CA'")
+
+	_, err = parseCertificate([]byte("garbage"), keyPem(t, leafKey))
+	assert.EqualError(t, err, "no certificates found in PEM data")
+
+	_, err = parseCertificate(leafPem, leafPem)
+	assert.EqualError(t, err, "no private key found in PEM data")
+}
+
+func TestCheckValidity(t *testing.T) {
+	ca, caKey, _ := testCert(t, "Test CA", nil, nil)
+	leaf, _, _ := testCert(t, "cdn.example.com", ca, caKey)
+	ca.NotAfter = time.Now().Add(time.Hour)
+
+	assert.NoError(t, checkValidity([]*x509.Certificate{leaf, ca}, time.Now()))
+
+	// intermediate expires before the leaf
+	later := time.Now().Add(2 * time.Hour)
+	assert.EqualError(t, checkValidity([]*x509.Certificate{leaf, ca}, later),
+		"certificate 'Test CA' expired on "+ca.NotAfter.Format(time.DateOnly))
+
+	earlier := time.Now().Add(-2 * time.Hour)
+	assert.EqualError(t, checkValidity([]*x509.Certificate{leaf, ca}, earlier),
+		"certificate 'cdn.example.com' is not valid until "+leaf.NotBefore.Format(time.DateOnly))
+}
+
+func TestExpiresIn(t *testing.T) {
+	now := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	assert.Equal(t, "expired", expiresIn(now.Add(-time.Minute), now))
+	assert.Equal(t, "expires today", expiresIn(now.Add(time.Hour), now))
+	assert.Equal(t, "expires in 1 day", expiresIn(now.Add(30*time.Hour), now))
+	assert.Equal(t, "expires in 90 days", expiresIn(now.AddDate(0, 0, 90), now))
+}
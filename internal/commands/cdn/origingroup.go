@@ -0,0 +1,271 @@
+package cdn
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+
+	"github.com/spf13/cobra"
+
+	e "github.com/G-core/gcore-cli/internal/errors"
+	"github.com/G-core/gcore-cli/internal/human"
+	"github.com/G-core/gcore-cli/internal/output"
+	"github.com/G-core/gcore-cli/internal/sure"
+)
+
+type originGroupInfo struct {
+	Id                  int            `json:"id"`
+	Name                string         `json:"name"`
+	UseNext             bool           `json:"useNext"`
+	Sources             []originSource `json:"sources"`
+	HasRelatedResources bool           `json:"has_related_resources,omitempty"`
+}
+
+type originSource struct {
+	Source  string `json:"source"`
+	Backup  bool   `json:"backup"`
+	Enabled bool   `json:"enabled"`
+}
+
+// originGroupView is the origin group, shown by "origin-group show" command
+type originGroupView struct {
+	ID      int
+	Name    string
+	UseNext bool
+	InUse   bool
+	Sources []originSource
+}
+
+func originGroup() *cobra.Command {
+	var cmdGroup = &cobra.Command{
+		Use:     "origin-group <subcommand>",
+		Aliases: []string{"origin-groups", "og"},
+		Short:   "Origin groups management",
+		Long: `Origin groups management. Origin group is the list of hosts, CDN takes
+content from. Backup origins are used, when all main origins are unavailable.`,
+		Args: cobra.MinimumNArgs(1),
+	}
+
+	var cmdList = &cobra.Command{
+		Use:     "list",
+		Aliases: []string{"ls"},
+		Short:   "Show list of origin groups",
+		Args:    cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			var groups []originGroupInfo
+			body, err := client.Get("/origin_groups", nil, &groups)
+			if err != nil {
+				return fmt.Errorf("getting the list of origin groups: %w", err)
+			}
+
+			if output.Format(cmd) == output.FmtJSON {
+				fmt.Println(string(body))
+				return nil
+			}
+
+			if len(groups) == 0 {
+				fmt.Printf("you have no origin groups\n")
+				return nil
+			}
+
+			table := make([][]string, len(groups)+1)
+			table[0] = []string{"ID", "Name", "Origins", "Backup", "In use"}
+			for i, g := range groups {
+				main, backup := 0, 0
+				for _, s := range g.Sources {
+					if s.Backup {
+						backup++
+					} else {
+						main++
+					}
+				}
+				table[i+1] = []string{
+					strconv.Itoa(g.Id),
+					g.Name,
+					strconv.Itoa(main),
+					strconv.Itoa(backup),
+					strconv.FormatBool(g.HasRelatedResources),
+				}
+			}
+			output.Table(table, output.Format(cmd))
+			return nil
+		},
+	}
+
+	var cmdShow = &cobra.Command{
+		Use:     "show <origin_group>",
+		Aliases: []string{"get"},
+		Short:   "Show origin group details",
+		Args:    cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			id, err := originGroupId(args[0])
+			if err != nil {
+				return err
+			}
+			var g originGroupInfo
+			body, err := client.Get("/origin_groups/"+strconv.Itoa(id), nil, &g)
+			if err != nil {
+				return fmt.Errorf("getting origin group details: %w", err)
+			}
+
+			if output.Format(cmd) == output.FmtJSON {
+				fmt.Println(string(body))
+				return nil
+			}
+
+			str, err := human.Marshal(&originGroupView{
+				ID:      g.Id,
+				Name:    g.Name,
+				UseNext: g.UseNext,
+				InUse:   g.HasRelatedResources,
+				Sources: g.Sources,
+			}, &human.MarshalOpt{
+				Sections: []*human.MarshalSection{{FieldName: "Sources", Title: "Origins"}},
+			})
+			if err != nil {
+				return err
+			}
+			fmt.Println(str)
+			return nil
+		},
+	}
+
+	var cmdCreate = &cobra.Command{
+		Use:   "create <name>",
+		Short: "Create new origin group",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			req, err := parseOriginGroup(cmd)
+			if err != nil {
+				return err
+			}
+			req["name"] = args[0]
+
+			var g originGroupInfo
+			body, err := client.Post("/origin_groups", req, &g)
+			if err != nil {
+				return fmt.Errorf("creating the origin group: %w", err)
+			}
+
+			if output.Format(cmd) == output.FmtJSON {
+				fmt.Println(string(body))
+				return nil
+			}
+			fmt.Printf("Origin group %d created\n", g.Id)
+			return nil
+		},
+	}
+	originGroupFlags(cmdCreate)
+	cmdCreate.MarkFlagRequired("origin")
+
+	var cmdUpdate = &cobra.Command{
+		Use:   "update <origin_group>",
+		Short: "Change the origin group",
+		Long: `Change the origin group. Only properties, specified with flags, are
+changed. "--origin" and "--backup" flags replace the whole list of origins.`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			id, err := originGroupId(args[0])
+			if err != nil {
+				return err
+			}
+			req, err := parseOriginGroup(cmd)
+			if err != nil {
+				return err
+			}
+			if cmd.Flags().Changed("name") {
+				name, err := cmd.Flags().GetString("name")
+				if err != nil {
+					return err
+				}
+				req["name"] = name
+			}
+			if len(req) == 0 {
+				return errors.New("nothing to change, specify properties with flags")
+			}
+
+			body, err := client.Patch("/origin_groups/"+strconv.Itoa(id), req, nil)
+			if err != nil {
+				return fmt.Errorf("changing the origin group: %w", err)
+			}
+
+			if output.Format(cmd) == output.FmtJSON {
+				fmt.Println(string(body))
+				return nil
+			}
+			fmt.Printf("Origin group %d changed\n", id)
+			return nil
+		},
+	}
+	originGroupFlags(cmdUpdate)
+	cmdUpdate.Flags().String("name", "", "New name of the group")
+
+	var cmdDelete = &cobra.Command{
+		Use:     "delete <origin_group>",
+		Aliases: []string{"rm"},
+		Short:   "Delete the origin group",
+		Args:    cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			id, err := originGroupId(args[0])
+			if err != nil {
+				return err
+			}
+			if !sure.AreYou(cmd, fmt.Sprintf("delete origin group %d", id)) {
+				return e.ErrAborted
+			}
+
+			if _, err := client.Delete("/origin_groups/"+strconv.Itoa(id), nil, nil); err != nil {
+				return fmt.Errorf("deleting the origin group: %w", err)
+			}
+			fmt.Printf("Origin group %d deleted\n", id)
+			return nil
+		},
+	}
+
+	cmdGroup.AddCommand(cmdList, cmdShow, cmdCreate, cmdUpdate, cmdDelete)
+	return cmdGroup
+}
+
+func originGroupFlags(cmd *cobra.Command) {
+	cmd.Flags().StringSlice("origin", nil, "Origin host, like 'example.com' or '1.2.3.4:8080', can be repeated")
+	cmd.Flags().StringSlice("backup", nil, "Backup origin host, can be repeated")
+	cmd.Flags().Bool("use-next", false, "Try the next origin, when the origin responds with error")
+}
+
+// parseOriginGroup returns request body fields for properties, that are
+// specified with flags
+func parseOriginGroup(cmd *cobra.Command) (map[string]any, error) {
+	flags := cmd.Flags()
+	req := map[string]any{}
+	if flags.Changed("use-next") {
+		useNext, err := flags.GetBool("use-next")
+		if err != nil {
+			return nil, err
+		}
+		req["useNext"] = useNext
+	}
+	if !flags.Changed("origin") && !flags.Changed("backup") {
+		return req, nil
+	}
+
+	origins, err := flags.GetStringSlice("origin")
+	if err != nil {
+		return nil, err
+	}
+	backups, err := flags.GetStringSlice("backup")
+	if err != nil {
+		return nil, err
+	}
+	if len(origins) == 0 {
+		return nil, errors.New("origin group must have at least one main origin")
+	}
+	var sources []originSource
+	for _, o := range origins {
+		sources = append(sources, originSource{Source: o, Enabled: true})
+	}
+	for _, o := range backups {
+		sources = append(sources, originSource{Source: o, Backup: true, Enabled: true})
+	}
+	req["sources"] = sources
+	return req, nil
+}
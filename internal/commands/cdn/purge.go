@@ -0,0 +1,162 @@
+package cdn
+
+import (
+	"fmt"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	e "github.com/G-core/gcore-cli/internal/errors"
+	"github.com/G-core/gcore-cli/internal/output"
+	"github.com/G-core/gcore-cli/internal/sure"
+)
+
+func purge() *cobra.Command {
+	var cmdPurge = &cobra.Command{
+		Use:   "purge <resource>",
+		Short: "Purge the CDN resource cache",
+		Long: `Purge the CDN resource cache. Content is purged by exact URLs ("--url"
+flag), by patterns with '*' wildcard ("--pattern" flag), or completely
+("--all" flag). URLs can be specified either as paths, like "/img/a.png",
+or as full URLs with the scheme, like "https://cdn.example.com/img/a.png".
+Patterns, matching all content, like "/*", need the same confirmation as
+"--all" flag.`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			flags := cmd.Flags()
+			urls, err := flags.GetStringArray("url")
+			if err != nil {
+				return err
+			}
+			patterns, err := flags.GetStringArray("pattern")
+			if err != nil {
+				return err
+			}
+			all, err := flags.GetBool("all")
+			if err != nil {
+				return err
+			}
+			id, err := resourceId(args[0])
+			if err != nil {
+				return err
+			}
+
+			var req map[string][]string
+			var what string
+			switch {
+			case all:
+				if !sure.AreYou(cmd, fmt.Sprintf("purge all cached content of CDN resource %d", id)) {
+					return e.ErrAborted
+				}
+				req = map[string][]string{"paths": {}}
+				what = "all content"
+			case len(urls) > 0:
+				paths, err := purgePaths(urls)
+				if err != nil {
+					return err
+				}
+				req = map[string][]string{"urls": paths}
+				what = plural(len(paths), "URL")
+			default:
+				paths, err := purgePaths(patterns)
+				if err != nil {
+					return err
+				}
+				if slices.Contains(paths, "/*") && !sure.AreYou(cmd, fmt.Sprintf("purge all cached content of CDN resource %d", id)) {
+					return e.ErrAborted
+				}
+				req = map[string][]string{"paths": paths}
+				what = plural(len(paths), "pattern")
+			}
+
+			body, err := client.Post("/resources/"+strconv.Itoa(id)+"/purge", req, nil)
+			if err != nil {
+				return fmt.Errorf("purging the cache: %w", err)
+			}
+
+			if output.Format(cmd) == output.FmtJSON {
+				fmt.Println(string(body))
+				return nil
+			}
+			fmt.Printf("Purge of %s requested for CDN resource %d\n", what, id)
+			return nil
+		},
+	}
+	cmdPurge.Flags().StringArray("url", nil, "URL to purge, can be repeated")
+	cmdPurge.Flags().StringArray("pattern", nil, "URL pattern to purge, like '/img/*', can be repeated")
+	cmdPurge.Flags().Bool("all", false, "Purge all cached content")
+	cmdPurge.MarkFlagsOneRequired("url", "pattern", "all")
+	cmdPurge.MarkFlagsMutuallyExclusive("url", "pattern", "all")
+
+	return cmdPurge
+}
+
+func prefetch() *cobra.Command {
+	var cmdPrefetch = &cobra.Command{
+		Use:   "prefetch <resource> <url>...",
+		Short: "Load content into the CDN resource cache",
+		Long: `Load content into the CDN resource cache in advance, so first requests
+are served from the cache. URLs can be specified either as paths, like
+"/video/a.mp4", or as full URLs with the scheme.`,
+		Args: cobra.MinimumNArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			id, err := resourceId(args[0])
+			if err != nil {
+				return err
+			}
+			paths, err := purgePaths(args[1:])
+			if err != nil {
+				return err
+			}
+
+			body, err := client.Post("/resources/"+strconv.Itoa(id)+"/prefetch", map[string][]string{"paths": paths}, nil)
+			if err != nil {
+				return fmt.Errorf("prefetching the content: %w", err)
+			}
+
+			if output.Format(cmd) == output.FmtJSON {
+				fmt.Println(string(body))
+				return nil
+			}
+			fmt.Printf("Prefetch of %s requested for CDN resource %d\n", plural(len(paths), "URL"), id)
+			return nil
+		},
+	}
+
+	return cmdPrefetch
+}
+
+// purgePaths converts URLs into paths with query, as expected by API. Only
+// arguments with the scheme are parsed as full URLs, others are paths.
+func purgePaths(urls []string) ([]string, error) {
+	paths := make([]string, 0, len(urls))
+	for _, s := range urls {
+		if !strings.Contains(s, "://") {
+			paths = append(paths, "/"+strings.TrimPrefix(s, "/"))
+			continue
+		}
+		u, err := url.Parse(s)
+		if err != nil || u.Host == "" {
+			return nil, fmt.Errorf("invalid URL '%s'", s)
+		}
+		path := u.EscapedPath()
+		if path == "" {
+			path = "/"
+		}
+		if u.RawQuery != "" {
+			path += "?" + u.RawQuery
+		}
+		paths = append(paths, path)
+	}
+	return paths, nil
+}
+
+func plural(n int, noun string) string {
+	if n == 1 {
+		return "1 " + noun
+	}
+	return strconv.Itoa(n) + " " + noun + "s"
+}
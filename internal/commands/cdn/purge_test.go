@@ -0,0 +1,34 @@
+package cdn
+
+import (
+	"testing"
+
+	"github.com/alecthomas/assert"
+)
+
+func TestPurgePaths(t *testing.T) {
+	paths, err := purgePaths([]string{
+		"/img/a.png",
+		"https://cdn.example.com/img/b.png?v=2",
+		"http://cdn.example.com",
+		"img/*",
+		"img/a.png",
+		"*.jpg",
+		"index.html",
+		"cdn.example.com/video/*",
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{
+		"/img/a.png",
+		"/img/b.png?v=2",
+		"/",
+		"/img/*",
+		"/img/a.png",
+		"/*.jpg",
+		"/index.html",
+		"/cdn.example.com/video/*",
+	}, paths)
+
+	_, err = purgePaths([]string{"https://"})
+	assert.EqualError(t, err, "invalid URL 'https://'")
+}
@@ -0,0 +1,361 @@
+package cdn
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	e "github.com/G-core/gcore-cli/internal/errors"
+	"github.com/G-core/gcore-cli/internal/human"
+	"github.com/G-core/gcore-cli/internal/output"
+	"github.com/G-core/gcore-cli/internal/sure"
+)
+
+type resourceInfo struct {
+	Id                 int      `json:"id"`
+	Cname              string   `json:"cname"`
+	Description        string   `json:"description"`
+	Active             bool     `json:"active"`
+	Status             string   `json:"status"`
+	OriginGroup        int      `json:"originGroup"`
+	OriginGroupName    string   `json:"originGroup_name"`
+	OriginProtocol     string   `json:"originProtocol"`
+	SecondaryHostnames []string `json:"secondaryHostnames"`
+	SslEnabled         bool     `json:"sslEnabled"`
+	SslData            *int     `json:"sslData"`
+	Created            string   `json:"created"`
+	Updated            string   `json:"updated"`
+}
+
+// resourceView is the CDN resource, shown by "resource show" command
+type resourceView struct {
+	ID             int
+	Cname          string
+	Hostnames      string
+	Description    string
+	Active         bool
+	Status         string
+	OriginGroup    string
+	OriginProtocol string
+	SSL            string
+	Created        string
+	Updated        string
+}
+
+var originProtocols = []string{"HTTP", "HTTPS", "MATCH"}
+
+func resource() *cobra.Command {
+	var cmdResource = &cobra.Command{
+		Use:     "resource <subcommand>",
+		Aliases: []string{"resources"},
+		Short:   "CDN resources management",
+		Args:    cobra.MinimumNArgs(1),
+	}
+
+	var cmdList = &cobra.Command{
+		Use:     "list",
+		Aliases: []string{"ls"},
+		Short:   "Show list of CDN resources",
+		Args:    cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			var resources []resourceInfo
+			body, err := client.Get("/resources", nil, &resources)
+			if err != nil {
+				return fmt.Errorf("getting the list of CDN resources: %w", err)
+			}
+
+			if output.Format(cmd) == output.FmtJSON {
+				fmt.Println(string(body))
+				return nil
+			}
+
+			if len(resources) == 0 {
+				fmt.Printf("you have no CDN resources\n")
+				return nil
+			}
+
+			table := make([][]string, len(resources)+1)
+			table[0] = []string{"ID", "CNAME", "Status", "Active", "Origin group", "SSL"}
+			for i, r := range resources {
+				table[i+1] = []string{
+					strconv.Itoa(r.Id),
+					r.Cname,
+					r.Status,
+					strconv.FormatBool(r.Active),
+					r.OriginGroupName,
+					strconv.FormatBool(r.SslEnabled),
+				}
+			}
+			output.Table(table, output.Format(cmd))
+			return nil
+		},
+	}
+
+	var cmdShow = &cobra.Command{
+		Use:     "show <resource>",
+		Aliases: []string{"get"},
+		Short:   "Show CDN resource details",
+		Args:    cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			id, err := resourceId(args[0])
+			if err != nil {
+				return err
+			}
+			var r resourceInfo
+			body, err := client.Get("/resources/"+strconv.Itoa(id), nil, &r)
+			if err != nil {
+				return fmt.Errorf("getting CDN resource details: %w", err)
+			}
+
+			if output.Format(cmd) == output.FmtJSON {
+				fmt.Println(string(body))
+				return nil
+			}
+
+			str, err := human.Marshal(newResourceView(&r), nil)
+			if err != nil {
+				return err
+			}
+			fmt.Println(str)
+			return nil
+		},
+	}
+
+	var cmdCreate = &cobra.Command{
+		Use:   "create",
+		Short: "Create new CDN resource",
+		Long: `Create new CDN resource. Content is taken from the origin group, specified
+with "--origin-group" flag, or from the single origin ("--origin" flag), then
+the origin group is created automatically.`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			flags := cmd.Flags()
+			cname, err := flags.GetString("cname")
+			if err != nil {
+				return err
+			}
+			origin, err := flags.GetString("origin")
+			if err != nil {
+				return err
+			}
+			req, err := parseResourceProperties(cmd)
+			if err != nil {
+				return err
+			}
+			req["cname"] = cname
+			if origin != "" {
+				req["origin"] = origin
+			}
+			if _, ok := req["originProtocol"]; !ok {
+				req["originProtocol"] = "HTTP"
+			}
+
+			var r resourceInfo
+			body, err := client.Post("/resources", req, &r)
+			if err != nil {
+				return fmt.Errorf("creating the CDN resource: %w", err)
+			}
+
+			if output.Format(cmd) == output.FmtJSON {
+				fmt.Println(string(body))
+				return nil
+			}
+			fmt.Printf("CDN resource %d created\n", r.Id)
+			return nil
+		},
+	}
+	cmdCreate.Flags().String("cname", "", "Domain name to deliver content from")
+	cmdCreate.Flags().String("origin", "", "Origin host, like 'example.com' or '1.2.3.4:8080'")
+	resourcePropertiesFlags(cmdCreate)
+	cmdCreate.MarkFlagRequired("cname")
+	cmdCreate.MarkFlagsOneRequired("origin", "origin-group")
+	cmdCreate.MarkFlagsMutuallyExclusive("origin", "origin-group")
+
+	var cmdUpdate = &cobra.Command{
+		Use:   "update <resource>",
+		Short: "Change the CDN resource",
+		Long: `Change the CDN resource. Only properties, specified with flags, are
+changed. "--active=false" suspends content delivery.`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			id, err := resourceId(args[0])
+			if err != nil {
+				return err
+			}
+			req, err := parseResourceProperties(cmd)
+			if err != nil {
+				return err
+			}
+			if cmd.Flags().Changed("active") {
+				active, err := cmd.Flags().GetBool("active")
+				if err != nil {
+					return err
+				}
+				req["active"] = active
+			}
+			if len(req) == 0 {
+				return errors.New("nothing to change, specify properties with flags")
+			}
+
+			var r resourceInfo
+			body, err := client.Patch("/resources/"+strconv.Itoa(id), req, &r)
+			if err != nil {
+				return fmt.Errorf("changing the CDN resource: %w", err)
+			}
+
+			if output.Format(cmd) == output.FmtJSON {
+				fmt.Println(string(body))
+				return nil
+			}
+			fmt.Printf("CDN resource %d changed\n", id)
+			return nil
+		},
+	}
+	resourcePropertiesFlags(cmdUpdate)
+	cmdUpdate.Flags().Bool("active", true, "Deliver content, '--active=false' suspends the resource")
+
+	var cmdDelete = &cobra.Command{
+		Use:     "delete <resource>",
+		Aliases: []string{"rm"},
+		Short:   "Delete the CDN resource",
+		Long: `Delete the CDN resource. Active resource is suspended first, as API does
+not allow to delete resources, that deliver content. If deletion fails, the
+resource is activated back.`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			id, err := resourceId(args[0])
+			if err != nil {
+				return err
+			}
+			path := "/resources/" + strconv.Itoa(id)
+			var r resourceInfo
+			if _, err := client.Get(path, nil, &r); err != nil {
+				return fmt.Errorf("getting CDN resource details: %w", err)
+			}
+			if !sure.AreYou(cmd, fmt.Sprintf("delete CDN resource %d (%s)", id, r.Cname)) {
+				return e.ErrAborted
+			}
+
+			if r.Active {
+				if _, err := client.Patch(path, map[string]bool{"active": false}, nil); err != nil {
+					return fmt.Errorf("suspending the CDN resource: %w", err)
+				}
+			}
+			if _, err := client.Delete(path, nil, nil); err != nil {
+				if !r.Active {
+					return fmt.Errorf("deleting the CDN resource: %w", err)
+				}
+				if _, reErr := client.Patch(path, map[string]bool{"active": true}, nil); reErr != nil {
+					return &e.CliError{
+						Err:     fmt.Errorf("deleting the CDN resource: %w", err),
+						Details: fmt.Sprintf("resource was suspended before deletion and cannot be activated back: %v", reErr),
+						Hint:    fmt.Sprintf("Activate it with \"cdn resource update %d --active\"", id),
+						Code:    1,
+					}
+				}
+				return fmt.Errorf("deleting the CDN resource, it is left active: %w", err)
+			}
+			fmt.Printf("CDN resource %d deleted\n", id)
+			return nil
+		},
+	}
+
+	cmdResource.AddCommand(cmdList, cmdShow, cmdCreate, cmdUpdate, cmdDelete)
+	return cmdResource
+}
+
+func resourcePropertiesFlags(cmd *cobra.Command) {
+	cmd.Flags().String("origin-group", "", "Origin group ID or name")
+	cmd.Flags().String("origin-protocol", "", "Protocol of requests to the origin, 'HTTP', 'HTTPS' or 'MATCH' (default is HTTP)")
+	cmd.Flags().StringSlice("secondary-hostname", nil, "Additional domain name, can be repeated")
+	cmd.Flags().String("description", "", "Resource description")
+	cmd.Flags().String("certificate", "", "SSL certificate ID or name, 'none' disables HTTPS")
+	cmd.RegisterFlagCompletionFunc("origin-protocol", cobra.FixedCompletions(originProtocols, cobra.ShellCompDirectiveNoFileComp))
+}
+
+// parseResourceProperties returns request body fields for properties, that
+// are specified with flags
+func parseResourceProperties(cmd *cobra.Command) (map[string]any, error) {
+	flags := cmd.Flags()
+	req := map[string]any{}
+	if flags.Changed("origin-group") {
+		group, err := flags.GetString("origin-group")
+		if err != nil {
+			return nil, err
+		}
+		id, err := originGroupId(group)
+		if err != nil {
+			return nil, err
+		}
+		req["originGroup"] = id
+	}
+	if flags.Changed("origin-protocol") {
+		protocol, err := flags.GetString("origin-protocol")
+		if err != nil {
+			return nil, err
+		}
+		protocol = strings.ToUpper(protocol)
+		if !slices.Contains(originProtocols, protocol) {
+			return nil, fmt.Errorf("invalid origin protocol '%s', use one of: %s", protocol, strings.Join(originProtocols, ", "))
+		}
+		req["originProtocol"] = protocol
+	}
+	if flags.Changed("secondary-hostname") {
+		hostnames, err := flags.GetStringSlice("secondary-hostname")
+		if err != nil {
+			return nil, err
+		}
+		req["secondaryHostnames"] = hostnames
+	}
+	if flags.Changed("description") {
+		description, err := flags.GetString("description")
+		if err != nil {
+			return nil, err
+		}
+		req["description"] = description
+	}
+	if flags.Changed("certificate") {
+		cert, err := flags.GetString("certificate")
+		if err != nil {
+			return nil, err
+		}
+		if cert == "none" {
+			req["sslEnabled"] = false
+			req["sslData"] = nil
+		} else {
+			id, err := certificateId(cert)
+			if err != nil {
+				return nil, err
+			}
+			req["sslEnabled"] = true
+			req["sslData"] = id
+		}
+	}
+	return req, nil
+}
+
+func newResourceView(r *resourceInfo) *resourceView {
+	view := &resourceView{
+		ID:             r.Id,
+		Cname:          r.Cname,
+		Hostnames:      strings.Join(r.SecondaryHostnames, ", "),
+		Description:    r.Description,
+		Active:         r.Active,
+		Status:         r.Status,
+		OriginGroup:    fmt.Sprintf("%s (%d)", r.OriginGroupName, r.OriginGroup),
+		OriginProtocol: r.OriginProtocol,
+		SSL:            "disabled",
+		Created:        r.Created,
+		Updated:        r.Updated,
+	}
+	if r.SslEnabled && r.SslData != nil {
+		view.SSL = "certificate " + strconv.Itoa(*r.SslData)
+	} else if r.SslEnabled {
+		view.SSL = "enabled"
+	}
+	return view
+}
@@ -0,0 +1,40 @@
+package cdn
+
+import (
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/alecthomas/assert"
+	"github.com/spf13/cobra"
+)
+
+func TestResourceDeleteReactivates(t *testing.T) {
+	var requests []string
+	testClient(t, func(w http.ResponseWriter, r *http.Request) {
+		buf, _ := io.ReadAll(r.Body)
+		requests = append(requests, r.Method+" "+string(buf))
+		switch r.Method {
+		case http.MethodGet:
+			io.WriteString(w, `{"id":1,"cname":"cdn.example.com","active":true}`)
+		case http.MethodDelete:
+			w.WriteHeader(http.StatusConflict)
+			io.WriteString(w, `{"message":"Resource is locked."}`)
+		}
+	})
+
+	root := &cobra.Command{Use: "cdn"}
+	root.PersistentFlags().BoolP("force", "f", false, "")
+	root.AddCommand(resource())
+	root.SetArgs([]string{"resource", "delete", "1", "-f"})
+	root.SilenceErrors, root.SilenceUsage = true, true
+
+	err := root.Execute()
+	assert.EqualError(t, err, "deleting the CDN resource, it is left active: Resource is locked.")
+	assert.Equal(t, []string{
+		"GET ",
+		`PATCH {"active":false}`,
+		"DELETE ",
+		`PATCH {"active":true}`,
+	}, requests)
+}
@@ -0,0 +1,347 @@
+package cdn
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/G-core/gcore-cli/internal/apiclient"
+	e "github.com/G-core/gcore-cli/internal/errors"
+	"github.com/G-core/gcore-cli/internal/output"
+	"github.com/G-core/gcore-cli/internal/sure"
+)
+
+type ruleInfo struct {
+	Id             int                        `json:"id"`
+	Name           string                     `json:"name"`
+	Rule           string                     `json:"rule"`
+	RuleType       int                        `json:"ruleType"`
+	Active         bool                       `json:"active"`
+	Weight         int                        `json:"weight"`
+	OriginGroup    *int                       `json:"originGroup"`
+	OriginProtocol string                     `json:"overrideOriginProtocol"`
+	Options        map[string]json.RawMessage `json:"options"`
+}
+
+// enabledOptions returns sorted names of the rule options, that are set
+func (r *ruleInfo) enabledOptions() []string {
+	var names []string
+	for name, raw := range r.Options {
+		var opt struct {
+			Enabled bool `json:"enabled"`
+		}
+		if json.Unmarshal(raw, &opt) == nil && opt.Enabled {
+			names = append(names, name)
+		}
+	}
+	slices.Sort(names)
+	return names
+}
+
+func rule() *cobra.Command {
+	var cmdRule = &cobra.Command{
+		Use:     "rule <subcommand>",
+		Aliases: []string{"rules"},
+		Short:   "CDN resource rules management",
+		Long: `CDN resource rules management. Rules change resource options for
+requests with paths matching the pattern, like "/static/*" or "*.jpg".
+Rules can be specified by ID or name.`,
+		Args: cobra.MinimumNArgs(1),
+	}
+
+	var cmdList = &cobra.Command{
+		Use:     "list <resource>",
+		Aliases: []string{"ls"},
+		Short:   "Show rules of the CDN resource",
+		Args:    cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			id, err := resourceId(args[0])
+			if err != nil {
+				return err
+			}
+			rules, body, err := listRules(id)
+			if err != nil {
+				return err
+			}
+
+			if output.Format(cmd) == output.FmtJSON {
+				fmt.Println(string(body))
+				return nil
+			}
+
+			if len(rules) == 0 {
+				fmt.Printf("CDN resource has no rules\n")
+				return nil
+			}
+
+			table := make([][]string, len(rules)+1)
+			table[0] = []string{"ID", "Name", "Pattern", "Weight", "Active", "Options"}
+			for i, r := range rules {
+				table[i+1] = []string{
+					strconv.Itoa(r.Id),
+					r.Name,
+					r.Rule,
+					strconv.Itoa(r.Weight),
+					strconv.FormatBool(r.Active),
+					strings.Join(r.enabledOptions(), ", "),
+				}
+			}
+			output.Table(table, output.Format(cmd))
+			return nil
+		},
+	}
+
+	var cmdCreate = &cobra.Command{
+		Use:   "create <resource>",
+		Short: "Add the rule to the CDN resource",
+		Long: `Add the rule to the CDN resource. Common options have their own flags,
+others can be set with "--option name=json", like
+"--option 'cors={\"enabled\":true,\"value\":[\"*\"]}'".`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			id, err := resourceId(args[0])
+			if err != nil {
+				return err
+			}
+			req, err := parseRule(cmd)
+			if err != nil {
+				return err
+			}
+			req["ruleType"] = 0
+			if _, ok := req["active"]; !ok {
+				req["active"] = true
+			}
+
+			var r ruleInfo
+			body, err := client.Post("/resources/"+strconv.Itoa(id)+"/rules", req, &r)
+			if err != nil {
+				return fmt.Errorf("creating the rule: %w", err)
+			}
+
+			if output.Format(cmd) == output.FmtJSON {
+				fmt.Println(string(body))
+				return nil
+			}
+			fmt.Printf("Rule %d created\n", r.Id)
+			return nil
+		},
+	}
+	ruleFlags(cmdCreate)
+	cmdCreate.MarkFlagRequired("name")
+	cmdCreate.MarkFlagRequired("pattern")
+
+	var cmdUpdate = &cobra.Command{
+		Use:   "update <resource> <rule>",
+		Short: "Change the rule",
+		Long: `Change the rule. Only properties and options, specified with flags, are
+changed.`,
+		Args: cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			id, ruleId, err := ruleIds(args[0], args[1])
+			if err != nil {
+				return err
+			}
+			req, err := parseRule(cmd)
+			if err != nil {
+				return err
+			}
+			if len(req) == 0 {
+				return errors.New("nothing to change, specify properties with flags")
+			}
+
+			var r ruleInfo
+			body, err := client.Patch("/resources/"+strconv.Itoa(id)+"/rules/"+strconv.Itoa(ruleId), req, &r)
+			if err != nil {
+				return fmt.Errorf("changing the rule: %w", err)
+			}
+
+			if output.Format(cmd) == output.FmtJSON {
+				fmt.Println(string(body))
+				return nil
+			}
+			fmt.Printf("Rule %d changed\n", ruleId)
+			return nil
+		},
+	}
+	ruleFlags(cmdUpdate)
+
+	var cmdDelete = &cobra.Command{
+		Use:     "delete <resource> <rule>",
+		Aliases: []string{"rm"},
+		Short:   "Delete the rule",
+		Args:    cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			id, ruleId, err := ruleIds(args[0], args[1])
+			if err != nil {
+				return err
+			}
+			if !sure.AreYou(cmd, fmt.Sprintf("delete rule %d of CDN resource %d", ruleId, id)) {
+				return e.ErrAborted
+			}
+
+			if _, err := client.Delete("/resources/"+strconv.Itoa(id)+"/rules/"+strconv.Itoa(ruleId), nil, nil); err != nil {
+				return fmt.Errorf("deleting the rule: %w", err)
+			}
+			fmt.Printf("Rule %d deleted\n", ruleId)
+			return nil
+		},
+	}
+
+	cmdRule.AddCommand(cmdList, cmdCreate, cmdUpdate, cmdDelete)
+	return cmdRule
+}
+
+func listRules(resourceId int) ([]ruleInfo, []byte, error) {
+	var rules []ruleInfo
+	body, err := client.Get("/resources/"+strconv.Itoa(resourceId)+"/rules", nil, &rules)
+	if err != nil {
+		return nil, nil, fmt.Errorf("getting the list of rules: %w", err)
+	}
+	return rules, body, nil
+}
+
+// ruleIds resolves the CDN resource and its rule, specified by ID or name
+func ruleIds(resource, rule string) (int, int, error) {
+	id, err := resourceId(resource)
+	if err != nil {
+		return 0, 0, err
+	}
+	if ruleId, err := strconv.Atoi(rule); err == nil {
+		return id, ruleId, nil
+	}
+
+	rules, _, err := listRules(id)
+	if err != nil {
+		return 0, 0, err
+	}
+	var names []string
+	for _, r := range rules {
+		if r.Name == rule {
+			return id, r.Id, nil
+		}
+		names = append(names, r.Name)
+	}
+	return 0, 0, apiclient.NotFound("rule", rule, names)
+}
+
+func ruleFlags(cmd *cobra.Command) {
+	cmd.Flags().String("name", "", "Rule name")
+	cmd.Flags().String("pattern", "", "Path pattern, like '/static/*' or '*.jpg'")
+	cmd.Flags().Int("weight", 0, "Rule priority, rules with higher weight are applied later")
+	cmd.Flags().Bool("active", true, "Apply the rule, '--active=false' disables it")
+	cmd.Flags().String("origin-group", "", "Origin group ID or name to take content from")
+	cmd.Flags().String("origin-protocol", "", "Protocol of requests to the origin, 'HTTP', 'HTTPS' or 'MATCH'")
+	cmd.Flags().Duration("edge-ttl", 0, "Cache time on CDN servers, like '1h' (0 - don't cache)")
+	cmd.Flags().Duration("browser-ttl", 0, "Cache time in browsers, like '10m' (0 - don't cache)")
+	cmd.Flags().Bool("force-https", false, "Redirect HTTP requests to HTTPS")
+	cmd.Flags().Bool("ignore-query-string", false, "Ignore query string when caching")
+	cmd.Flags().StringArray("option", nil, "Option in 'name=json' format, can be repeated")
+	cmd.RegisterFlagCompletionFunc("origin-protocol", cobra.FixedCompletions(originProtocols, cobra.ShellCompDirectiveNoFileComp))
+}
+
+// parseRule returns request body fields for rule properties and options,
+// that are specified with flags
+func parseRule(cmd *cobra.Command) (map[string]any, error) {
+	flags := cmd.Flags()
+	req := map[string]any{}
+	for flag, field := range map[string]string{"name": "name", "pattern": "rule"} {
+		if flags.Changed(flag) {
+			val, err := flags.GetString(flag)
+			if err != nil {
+				return nil, err
+			}
+			req[field] = val
+		}
+	}
+	if flags.Changed("weight") {
+		weight, err := flags.GetInt("weight")
+		if err != nil {
+			return nil, err
+		}
+		req["weight"] = weight
+	}
+	if flags.Changed("active") {
+		active, err := flags.GetBool("active")
+		if err != nil {
+			return nil, err
+		}
+		req["active"] = active
+	}
+	if flags.Changed("origin-group") {
+		group, err := flags.GetString("origin-group")
+		if err != nil {
+			return nil, err
+		}
+		id, err := originGroupId(group)
+		if err != nil {
+			return nil, err
+		}
+		req["originGroup"] = id
+	}
+	if flags.Changed("origin-protocol") {
+		protocol, err := flags.GetString("origin-protocol")
+		if err != nil {
+			return nil, err
+		}
+		protocol = strings.ToUpper(protocol)
+		if !slices.Contains(originProtocols, protocol) {
+			return nil, fmt.Errorf("invalid origin protocol '%s', use one of: %s", protocol, strings.Join(originProtocols, ", "))
+		}
+		req["overrideOriginProtocol"] = protocol
+	}
+
+	options, err := parseRuleOptions(cmd)
+	if err != nil {
+		return nil, err
+	}
+	if len(options) > 0 {
+		req["options"] = options
+	}
+	return req, nil
+}
+
+func parseRuleOptions(cmd *cobra.Command) (map[string]any, error) {
+	flags := cmd.Flags()
+	options := map[string]any{}
+	for flag, name := range map[string]string{"edge-ttl": "edge_cache_settings", "browser-ttl": "browser_cache_settings"} {
+		if flags.Changed(flag) {
+			ttl, err := flags.GetDuration(flag)
+			if err != nil {
+				return nil, err
+			}
+			options[name] = map[string]any{"enabled": true, "value": strconv.Itoa(int(ttl/time.Second)) + "s"}
+		}
+	}
+	for flag, name := range map[string]string{"force-https": "redirect_http_to_https", "ignore-query-string": "ignoreQueryString"} {
+		if flags.Changed(flag) {
+			val, err := flags.GetBool(flag)
+			if err != nil {
+				return nil, err
+			}
+			options[name] = map[string]any{"enabled": val, "value": val}
+		}
+	}
+
+	raw, err := flags.GetStringArray("option")
+	if err != nil {
+		return nil, err
+	}
+	for _, o := range raw {
+		name, val, ok := strings.Cut(o, "=")
+		if !ok || name == "" {
+			return nil, fmt.Errorf("invalid option '%s', expected 'name=json'", o)
+		}
+		var v any
+		if err := json.Unmarshal([]byte(val), &v); err != nil {
+			return nil, fmt.Errorf("invalid value of option '%s': %w", name, err)
+		}
+		options[name] = v
+	}
+	return options, nil
+}
@@ -0,0 +1,130 @@
+package cdn
+
+import (
+	"testing"
+
+	"github.com/alecthomas/assert"
+	"github.com/spf13/cobra"
+)
+
+func TestParseRule(t *testing.T) {
+	type TestCase struct {
+		Args     []string
+		Expected map[string]any
+		Err      string
+	}
+
+	run := func(tc *TestCase) func(*testing.T) {
+		return func(t *testing.T) {
+			cmd := &cobra.Command{}
+			ruleFlags(cmd)
+			assert.NoError(t, cmd.ParseFlags(tc.Args))
+
+			req, err := parseRule(cmd)
+			if tc.Err != "" {
+				assert.EqualError(t, err, tc.Err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tc.Expected, req)
+		}
+	}
+
+	t.Run("no flags", run(&TestCase{
+		Expected: map[string]any{},
+	}))
+	t.Run("only changed fields", run(&TestCase{
+		Args:     []string{"--name", "static", "--pattern", "/static/*", "--weight", "2", "--active=false"},
+		Expected: map[string]any{"name": "static", "rule": "/static/*", "weight": 2, "active": false},
+	}))
+	t.Run("origin protocol", run(&TestCase{
+		Args:     []string{"--origin-protocol", "https"},
+		Expected: map[string]any{"overrideOriginProtocol": "HTTPS"},
+	}))
+	t.Run("invalid origin protocol", run(&TestCase{
+		Args: []string{"--origin-protocol", "ftp"},
+		Err:  "invalid origin protocol 'FTP', use one of: HTTP, HTTPS, MATCH",
+	}))
+	t.Run("options", run(&TestCase{
+		Args: []string{"--edge-ttl", "1h"},
+		Expected: map[string]any{"options": map[string]any{
+			"edge_cache_settings": map[string]any{"enabled": true, "value": "3600s"},
+		}},
+	}))
+}
+
+func TestParseRuleOptions(t *testing.T) {
+	type TestCase struct {
+		Args     []string
+		Expected map[string]any
+		Err      string
+	}
+
+	run := func(tc *TestCase) func(*testing.T) {
+		return func(t *testing.T) {
+			cmd := &cobra.Command{}
+			ruleFlags(cmd)
+			assert.NoError(t, cmd.ParseFlags(tc.Args))
+
+			options, err := parseRuleOptions(cmd)
+			if tc.Err != "" {
+				assert.EqualError(t, err, tc.Err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tc.Expected, options)
+		}
+	}
+
+	t.Run("no flags", run(&TestCase{
+		Expected: map[string]any{},
+	}))
+	t.Run("ttl in seconds", run(&TestCase{
+		Args: []string{"--edge-ttl", "1h30m", "--browser-ttl", "90s"},
+		Expected: map[string]any{
+			"edge_cache_settings":    map[string]any{"enabled": true, "value": "5400s"},
+			"browser_cache_settings": map[string]any{"enabled": true, "value": "90s"},
+		},
+	}))
+	t.Run("zero ttl", run(&TestCase{
+		Args: []string{"--edge-ttl", "0"},
+		Expected: map[string]any{
+			"edge_cache_settings": map[string]any{"enabled": true, "value": "0s"},
+		},
+	}))
+	t.Run("bool options", run(&TestCase{
+		Args: []string{"--force-https", "--ignore-query-string=false"},
+		Expected: map[string]any{
+			"redirect_http_to_https": map[string]any{"enabled": true, "value": true},
+			"ignoreQueryString":      map[string]any{"enabled": false, "value": false},
+		},
+	}))
+	t.Run("json options", run(&TestCase{
+		Args: []string{
+			"--option", `gzipOn={"enabled":true,"value":true}`,
+			"--option", `cors={"enabled":true,"value":["*"]}`,
+		},
+		Expected: map[string]any{
+			"gzipOn": map[string]any{"enabled": true, "value": true},
+			"cors":   map[string]any{"enabled": true, "value": []any{"*"}},
+		},
+	}))
+	t.Run("json option overrides flag", run(&TestCase{
+		Args: []string{"--force-https", "--option", `redirect_http_to_https={"enabled":false}`},
+		Expected: map[string]any{
+			"redirect_http_to_https": map[string]any{"enabled": false},
+		},
+	}))
+	t.Run("missing value", run(&TestCase{
+		Args: []string{"--option", "gzipOn"},
+		Err:  "invalid option 'gzipOn', expected 'name=json'",
+	}))
+	t.Run("missing name", run(&TestCase{
+		Args: []string{"--option", "=true"},
+		Err:  "invalid option '=true', expected 'name=json'",
+	}))
+	t.Run("invalid json", run(&TestCase{
+		Args: []string{"--option", "gzipOn={enabled}"},
+		Err:  "invalid value of option 'gzipOn': invalid character 'e' looking for beginning of object key string",
+	}))
+}
@@ -0,0 +1,101 @@
+package cloud
+
+import (
+	"fmt"
+	"net/url"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/G-core/gcore-cli/internal/apiclient"
+	e "github.com/G-core/gcore-cli/internal/errors"
+)
+
+// list is the common format of API list responses
+type list[T any] struct {
+	Count   int `json:"count"`
+	Results []T `json:"results"`
+}
+
+// taskList is returned by API calls, that start long-running tasks
+type taskList struct {
+	Tasks []string `json:"tasks"`
+}
+
+var uuidRe = regexp.MustCompile(`^[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}$`)
+
+// regionPath returns API path of the resource in the project and region,
+// like "/v1/instances/<project>/<region>/<sub>..."
+func regionPath(cmd *cobra.Command, resource string, sub ...string) (string, error) {
+	project, region, err := projectRegion(cmd)
+	if err != nil {
+		return "", err
+	}
+	path := "/" + resource + "/" + strconv.Itoa(project) + "/" + strconv.Itoa(region)
+	for _, s := range sub {
+		path += "/" + url.PathEscape(s)
+	}
+	return path, nil
+}
+
+// resourcePath resolves the resource of the kind in the project and region
+// by ID or name, returning its API path and ID
+func resourcePath(cmd *cobra.Command, resource, kind, arg string) (string, string, error) {
+	path, err := regionPath(cmd, resource)
+	if err != nil {
+		return "", "", err
+	}
+	id, err := resolveId(path, kind, arg)
+	if err != nil {
+		return "", "", err
+	}
+	return path + "/" + id, id, nil
+}
+
+// resolveId returns ID of the resource, specified by ID or name. Names are
+// looked up in the list of resources, returned by API at path.
+func resolveId(path, kind, arg string) (string, error) {
+	if uuidRe.MatchString(arg) {
+		return arg, nil
+	}
+
+	var rsp list[struct {
+		Id           string `json:"id"`
+		Name         string `json:"name"`
+		InstanceId   string `json:"instance_id"`
+		InstanceName string `json:"instance_name"`
+	}]
+	if _, err := client.Get(path, nil, &rsp); err != nil {
+		return "", fmt.Errorf("getting the list of %ss: %w", kind, err)
+	}
+
+	var ids, names []string
+	for _, r := range rsp.Results {
+		id, name := r.Id, r.Name
+		if r.InstanceId != "" {
+			id, name = r.InstanceId, r.InstanceName
+		}
+		if name == arg || id == arg {
+			ids = append(ids, id)
+		}
+		if !slices.Contains(names, name) {
+			names = append(names, name)
+		}
+	}
+
+	switch len(ids) {
+	case 1:
+		return ids[0], nil
+	case 0:
+		return "", apiclient.NotFound(kind, arg, names)
+	}
+	return "", &e.CliError{
+		Err:     fmt.Errorf("%s name '%s' is ambiguous, it matches %d %ss", kind, arg, len(ids), kind),
+		Details: "matching IDs:\n" + strings.Join(ids, "\n"),
+		Hint:    "Specify " + kind + " by ID",
+		Code:    1,
+	}
+}
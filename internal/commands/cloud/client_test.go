@@ -0,0 +1,76 @@
+package cloud
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/alecthomas/assert"
+
+	"github.com/G-core/gcore-cli/internal/apiclient"
+	e "github.com/G-core/gcore-cli/internal/errors"
+)
+
+func testClient(t *testing.T, handler http.HandlerFunc) {
+	srv := httptest.NewServer(handler)
+	t.Cleanup(srv.Close)
+	client = &apiclient.Client{
+		BaseUrl: srv.URL,
+		Auth: func(ctx context.Context, req *http.Request) error {
+			req.Header.Set("Authorization", "APIKey test")
+			return nil
+		},
+		Doer:     http.DefaultClient,
+		ParseErr: e.ParseCloudErr,
+	}
+}
+
+func TestClientError(t *testing.T) {
+	testClient(t, func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/json":
+			w.WriteHeader(http.StatusBadRequest)
+			io.WriteString(w, `{"exception_class":"BadRequest","message":"Flavor not found"}`)
+		default:
+			w.WriteHeader(http.StatusBadGateway)
+			io.WriteString(w, `<html>bad gateway</html>`)
+		}
+	})
+
+	_, err := client.Get("/json", nil, nil)
+	var cliErr *e.CliError
+	assert.True(t, errors.As(err, &cliErr))
+	assert.Equal(t, "Flavor not found", cliErr.Error())
+
+	_, err = client.Get("/html", nil, nil)
+	assert.EqualError(t, err, "GET /html: 502 Bad Gateway")
+}
+
+func TestResolveId(t *testing.T) {
+	testClient(t, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "APIKey test", r.Header.Get("Authorization"))
+		io.WriteString(w, `{"count":3,"results":[
+			{"instance_id":"i1","instance_name":"web"},
+			{"instance_id":"i2","instance_name":"db"},
+			{"instance_id":"i3","instance_name":"db"}]}`)
+	})
+
+	id, err := resolveId("/instances", "instance", "web")
+	assert.NoError(t, err)
+	assert.Equal(t, "i1", id)
+
+	id, err = resolveId("/instances", "instance", "0b6d1a3e-5f5c-4c7e-9a4e-1f2d3c4b5a69")
+	assert.NoError(t, err)
+	assert.Equal(t, "0b6d1a3e-5f5c-4c7e-9a4e-1f2d3c4b5a69", id)
+
+	_, err = resolveId("/instances", "instance", "db")
+	assert.EqualError(t, err, "instance name 'db' is ambiguous, it matches 2 instances")
+
+	_, err = resolveId("/instances", "instance", "webb")
+	var cliErr *e.CliError
+	assert.True(t, errors.As(err, &cliErr))
+	assert.Equal(t, "Did you mean 'web'?", cliErr.Hint)
+}
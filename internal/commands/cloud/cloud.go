@@ -0,0 +1,119 @@
+package cloud
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/G-core/gcore-cli/internal/apiclient"
+	e "github.com/G-core/gcore-cli/internal/errors"
+	"github.com/G-core/gcore-cli/internal/sure"
+)
+
+var client *apiclient.Client
+
+// top-level Cloud command
+func Commands(baseUrl string, authFunc func(ctx context.Context, req *http.Request) error) (*cobra.Command, error) {
+	var cmdCloud = &cobra.Command{
+		Use:   "cloud <subcommand>",
+		Short: "Gcore Cloud resources management",
+		Long: `Gcore Cloud resources management. Most commands work with resources in
+the project and region, specified with "--project" and "--region" flags.
+Commands, that start long-running tasks, wait for their completion with
+"--wait" flag.`,
+		Args: cobra.MinimumNArgs(1),
+		PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+			// responses are cached the same way as for other commands, so
+			// mutating requests invalidate all cached data
+			doer, err := apiclient.CachedDoer(cmd, http.DefaultClient, apiclient.Identity(baseUrl, authFunc))
+			if err != nil {
+				return err
+			}
+			client = &apiclient.Client{
+				BaseUrl:  baseUrl + "/cloud",
+				Auth:     authFunc,
+				Doer:     doer,
+				ParseErr: e.ParseCloudErr,
+			}
+			return nil
+		},
+	}
+
+	cmdCloud.AddCommand(
+		project(),
+		region(),
+		instance(),
+		keypair(),
+		image(),
+		volume(),
+		snapshot(),
+		network(),
+		subnet(),
+		floatingIp(),
+		securityGroup(),
+		loadBalancer(),
+		k8s(),
+		tasks(),
+	)
+	return cmdCloud, nil
+}
+
+// projectRegion returns project and region IDs, specified by global flags
+func projectRegion(cmd *cobra.Command) (int, int, error) {
+	project, err := resolveProject(cmd)
+	if err != nil {
+		return 0, 0, err
+	}
+	region, err := resolveRegion(cmd)
+	if err != nil {
+		return 0, 0, err
+	}
+	if project == 0 {
+		return 0, 0, &e.CliError{
+			Err:  errors.New("project is not specified"),
+			Hint: `Specify it with "--project" flag or GCORE_PROJECT env variable, or set the default with "cloud project use" command`,
+			Code: 1,
+		}
+	}
+	if region == 0 {
+		return 0, 0, &e.CliError{
+			Err:  errors.New("region is not specified"),
+			Hint: `Specify it with "--region" flag or GCORE_REGION env variable, or set the default with "cloud region use" command`,
+			Code: 1,
+		}
+	}
+	return project, region, nil
+}
+
+// deleteCommand returns command to delete the resource of the kind by ID or
+// name, for resources that are deleted with the task
+func deleteCommand(resource, kind string) *cobra.Command {
+	return &cobra.Command{
+		Use:     "delete <" + kind + ">",
+		Aliases: []string{"rm"},
+		Short:   "Delete the " + kind,
+		Args:    cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			path, id, err := resourcePath(cmd, resource, kind, args[0])
+			if err != nil {
+				return err
+			}
+			if !sure.AreYou(cmd, fmt.Sprintf("delete %s %s", kind, id)) {
+				return e.ErrAborted
+			}
+
+			var tasks taskList
+			body, err := client.Delete(path, nil, &tasks)
+			if err != nil {
+				return fmt.Errorf("deleting the %s: %w", kind, err)
+			}
+			return handleTasks(cmd, body, tasks, func([]task) {
+				fmt.Printf("%s %s deleted\n", strings.ToUpper(kind[:1])+kind[1:], id)
+			})
+		},
+	}
+}
@@ -0,0 +1,189 @@
+package cloud
+
+import (
+	"fmt"
+	"net/url"
+	"strconv"
+
+	"github.com/dustin/go-humanize"
+	"github.com/spf13/cobra"
+
+	"github.com/G-core/gcore-cli/internal/output"
+)
+
+type imageInfo struct {
+	Id           string `json:"id"`
+	Name         string `json:"name"`
+	Status       string `json:"status"`
+	Size         uint64 `json:"size"`
+	MinDisk      int    `json:"min_disk"`
+	OsType       string `json:"os_type"`
+	OsDistro     string `json:"os_distro"`
+	OsVersion    string `json:"os_version"`
+	Architecture string `json:"architecture"`
+	Visibility   string `json:"visibility"`
+	DiskFormat   string `json:"disk_format"`
+	CreatedAt    string `json:"created_at"`
+}
+
+func image() *cobra.Command {
+	var cmdImage = &cobra.Command{
+		Use:     "image <subcommand>",
+		Aliases: []string{"images"},
+		Short:   "Boot images management",
+		Long: `Boot images management. Images can be specified by ID or name, including
+"--image" flag of "cloud instance create" and "cloud volume create" commands.`,
+		Args: cobra.MinimumNArgs(1),
+	}
+
+	var cmdList = &cobra.Command{
+		Use:     "list",
+		Aliases: []string{"ls"},
+		Short:   "Show list of images",
+		Args:    cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			path, err := regionPath(cmd, "v1/images")
+			if err != nil {
+				return err
+			}
+			query := url.Values{}
+			if private, _ := cmd.Flags().GetBool("private"); private {
+				query.Set("visibility", "private")
+			}
+
+			var rsp list[imageInfo]
+			body, err := client.Get(path, query, &rsp)
+			if err != nil {
+				return fmt.Errorf("getting the list of images: %w", err)
+			}
+
+			if output.Format(cmd) == output.FmtJSON {
+				fmt.Println(string(body))
+				return nil
+			}
+
+			if len(rsp.Results) == 0 {
+				fmt.Printf("you have no images\n")
+				return nil
+			}
+
+			table := make([][]string, len(rsp.Results)+1)
+			table[0] = []string{"ID", "Name", "Status", "OS", "Min disk", "Visibility"}
+			for i, img := range rsp.Results {
+				table[i+1] = []string{
+					img.Id,
+					img.Name,
+					img.Status,
+					img.OsDistro + " " + img.OsVersion,
+					strconv.Itoa(img.MinDisk) + " GiB",
+					img.Visibility,
+				}
+			}
+			output.Table(table, output.Format(cmd))
+			return nil
+		},
+	}
+	cmdList.Flags().Bool("private", false, "Show only custom images of the project")
+
+	var cmdShow = &cobra.Command{
+		Use:     "show <image>",
+		Aliases: []string{"get"},
+		Short:   "Show image details",
+		Args:    cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			path, id, err := imagePath(cmd, args[0])
+			if err != nil {
+				return err
+			}
+
+			var img imageInfo
+			body, err := client.Get(path, nil, &img)
+			if err != nil {
+				return fmt.Errorf("getting image details: %w", err)
+			}
+
+			if output.Format(cmd) == output.FmtJSON {
+				fmt.Println(string(body))
+				return nil
+			}
+
+			fmt.Printf(
+				"ID:\t    %s\nName:\t    %s\nStatus:\t    %s\nOS:\t    %s %s %s (%s)\nSize:\t    %s\nMin disk:   %d GiB\nFormat:\t    %s\nVisibility: %s\nCreated:    %s\n",
+				id,
+				img.Name,
+				img.Status,
+				img.OsType,
+				img.OsDistro,
+				img.OsVersion,
+				img.Architecture,
+				humanize.IBytes(img.Size),
+				img.MinDisk,
+				img.DiskFormat,
+				img.Visibility,
+				img.CreatedAt,
+			)
+			return nil
+		},
+	}
+
+	var cmdUpload = &cobra.Command{
+		Use:   "upload <name>",
+		Short: "Upload custom image",
+		Long: `Upload custom image. The image is downloaded by the cloud from the URL,
+specified with "--url" flag, so the file should be published first.
+Supported formats are qcow2, raw and iso.`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			flags := cmd.Flags()
+			req := map[string]any{"name": args[0], "ssh_key": "allow"}
+			for flag, field := range map[string]string{
+				"url":          "url",
+				"os-type":      "os_type",
+				"os-distro":    "os_distro",
+				"os-version":   "os_version",
+				"architecture": "architecture",
+				"firmware":     "hw_firmware_type",
+			} {
+				val, err := flags.GetString(flag)
+				if err != nil {
+					return err
+				}
+				if val != "" {
+					req[field] = val
+				}
+			}
+			path, err := regionPath(cmd, "v1/downloadimage")
+			if err != nil {
+				return err
+			}
+
+			var tasks taskList
+			body, err := client.Post(path, req, &tasks)
+			if err != nil {
+				return fmt.Errorf("uploading the image: %w", err)
+			}
+			return handleTasks(cmd, body, tasks, func(finished []task) {
+				for _, t := range finished {
+					for _, id := range t.createdIds("images") {
+						fmt.Printf("Image %s uploaded\n", id)
+					}
+				}
+			})
+		},
+	}
+	cmdUpload.Flags().String("url", "", "URL to download the image from")
+	cmdUpload.Flags().String("os-type", "linux", "OS type, 'linux' or 'windows'")
+	cmdUpload.Flags().String("os-distro", "", "OS distribution, like 'ubuntu'")
+	cmdUpload.Flags().String("os-version", "", "OS version, like '24.04'")
+	cmdUpload.Flags().String("architecture", "x86_64", "CPU architecture, 'x86_64' or 'aarch64'")
+	cmdUpload.Flags().String("firmware", "", "Firmware type, 'bios' or 'uefi'")
+	cmdUpload.MarkFlagRequired("url")
+
+	cmdImage.AddCommand(cmdList, cmdShow, cmdUpload)
+	return cmdImage
+}
+
+// imagePath resolves the image by ID or name, returning its API path and ID
+func imagePath(cmd *cobra.Command, arg string) (string, string, error) {
+	return resourcePath(cmd, "v1/images", "image", arg)
+}
@@ -0,0 +1,87 @@
+package cloud
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/alecthomas/assert"
+	"github.com/spf13/cobra"
+)
+
+// testCommand returns root command with the cloud global flags, running
+// the sub-command with args
+func testCommand(sub *cobra.Command, args ...string) *cobra.Command {
+	root := &cobra.Command{Use: "cloud"}
+	root.PersistentFlags().String("project", "1", "")
+	root.PersistentFlags().String("region", "2", "")
+	root.PersistentFlags().Bool("wait", false, "")
+	root.AddCommand(sub)
+	root.SetArgs(args)
+	root.SilenceErrors, root.SilenceUsage = true, true
+	return root
+}
+
+func TestImageUpload(t *testing.T) {
+	var requests []string
+	var req map[string]any
+	testClient(t, func(w http.ResponseWriter, r *http.Request) {
+		requests = append(requests, r.Method+" "+r.URL.Path)
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+		io.WriteString(w, `{"tasks":["t1"]}`)
+	})
+	t.Cleanup(func() { resolvedIds = map[string]int{} })
+
+	err := testCommand(image(), "image", "upload", "ubuntu", "--url", "https://example.com/ubuntu.qcow2", "--os-distro", "ubuntu").Execute()
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"POST /v1/downloadimage/1/2"}, requests)
+	assert.Equal(t, map[string]any{
+		"name":         "ubuntu",
+		"ssh_key":      "allow",
+		"url":          "https://example.com/ubuntu.qcow2",
+		"os_type":      "linux",
+		"os_distro":    "ubuntu",
+		"architecture": "x86_64",
+	}, req)
+
+	err = testCommand(image(), "image", "upload", "ubuntu").Execute()
+	assert.EqualError(t, err, `required flag(s) "url" not set`)
+}
+
+func TestImageList(t *testing.T) {
+	var query string
+	testClient(t, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/v1/images/1/2", r.URL.Path)
+		query = r.URL.RawQuery
+		io.WriteString(w, `{"count":0,"results":[]}`)
+	})
+	t.Cleanup(func() { resolvedIds = map[string]int{} })
+
+	assert.NoError(t, testCommand(image(), "image", "list").Execute())
+	assert.Equal(t, "", query)
+
+	assert.NoError(t, testCommand(image(), "image", "list", "--private").Execute())
+	assert.Equal(t, "visibility=private", query)
+}
+
+func TestImagePath(t *testing.T) {
+	testClient(t, func(w http.ResponseWriter, r *http.Request) {
+		io.WriteString(w, `{"count":2,"results":[
+			{"id":"0b6d1a3e-5f5c-4c7e-9a4e-1f2d3c4b5a69","name":"ubuntu-24.04"},
+			{"id":"7c1e2d3f-4a5b-4c6d-8e9f-0a1b2c3d4e5f","name":"debian-12"}]}`)
+	})
+	t.Cleanup(func() { resolvedIds = map[string]int{} })
+
+	cmd := &cobra.Command{}
+	cmd.Flags().String("project", "1", "")
+	cmd.Flags().String("region", "2", "")
+
+	path, id, err := imagePath(cmd, "debian-12")
+	assert.NoError(t, err)
+	assert.Equal(t, "7c1e2d3f-4a5b-4c6d-8e9f-0a1b2c3d4e5f", id)
+	assert.Equal(t, "/v1/images/1/2/7c1e2d3f-4a5b-4c6d-8e9f-0a1b2c3d4e5f", path)
+
+	_, _, err = imagePath(cmd, "debian-11")
+	assert.EqualError(t, err, "image 'debian-11' not found")
+}
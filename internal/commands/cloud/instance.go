@@ -0,0 +1,504 @@
+package cloud
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	e "github.com/G-core/gcore-cli/internal/errors"
+	"github.com/G-core/gcore-cli/internal/httpcache"
+	"github.com/G-core/gcore-cli/internal/output"
+	"github.com/G-core/gcore-cli/internal/poll"
+	"github.com/G-core/gcore-cli/internal/progress"
+	"github.com/G-core/gcore-cli/internal/sure"
+)
+
+const (
+	instanceStatusActive  = "ACTIVE"
+	instanceStatusShutoff = "SHUTOFF"
+	instanceStatusError   = "ERROR"
+)
+
+type instanceAddress struct {
+	Addr string `json:"addr"`
+	Type string `json:"type"`
+}
+
+type instanceInfo struct {
+	Id          string `json:"instance_id"`
+	Name        string `json:"instance_name"`
+	Status      string `json:"status"`
+	VmState     string `json:"vm_state"`
+	Created     string `json:"instance_created"`
+	KeypairName string `json:"keypair_name"`
+	Flavor      struct {
+		Id    string `json:"flavor_id"`
+		Vcpus int    `json:"vcpus"`
+		Ram   int    `json:"ram"`
+	} `json:"flavor"`
+	Addresses map[string][]instanceAddress `json:"addresses"`
+	Volumes   []struct {
+		Id string `json:"id"`
+	} `json:"volumes"`
+	SecurityGroups []struct {
+		Name string `json:"name"`
+	} `json:"security_groups"`
+}
+
+// addresses returns all instance IP addresses, sorted by network name
+func (i *instanceInfo) addresses() []string {
+	nets := make([]string, 0, len(i.Addresses))
+	for net := range i.Addresses {
+		nets = append(nets, net)
+	}
+	sort.Strings(nets)
+	var addrs []string
+	for _, net := range nets {
+		for _, a := range i.Addresses[net] {
+			addrs = append(addrs, a.Addr)
+		}
+	}
+	return addrs
+}
+
+type instanceVolume struct {
+	Source    string `json:"source"`
+	ImageId   string `json:"image_id,omitempty"`
+	Size      int    `json:"size"`
+	TypeName  string `json:"type_name"`
+	BootIndex int    `json:"boot_index"`
+}
+
+type instanceInterface struct {
+	Type      string `json:"type"`
+	NetworkId string `json:"network_id,omitempty"`
+}
+
+type instanceCreate struct {
+	Name        string              `json:"name"`
+	Flavor      string              `json:"flavor"`
+	Volumes     []instanceVolume    `json:"volumes"`
+	Interfaces  []instanceInterface `json:"interfaces"`
+	KeypairName string              `json:"keypair_name,omitempty"`
+	Username    string              `json:"username,omitempty"`
+	Password    string              `json:"password,omitempty"`
+}
+
+func instance() *cobra.Command {
+	var cmdInstance = &cobra.Command{
+		Use:     "instance <subcommand>",
+		Aliases: []string{"instances", "vm"},
+		Short:   "Virtual machine instances management",
+		Long: `Virtual machine instances management. Instances can be specified by ID
+or name.`,
+		Args: cobra.MinimumNArgs(1),
+	}
+
+	var cmdList = &cobra.Command{
+		Use:     "list",
+		Aliases: []string{"ls"},
+		Short:   "Show list of instances",
+		Args:    cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			path, err := regionPath(cmd, "v1/instances")
+			if err != nil {
+				return err
+			}
+			query := url.Values{}
+			if name, _ := cmd.Flags().GetString("name"); name != "" {
+				query.Set("name", name)
+			}
+
+			var rsp list[instanceInfo]
+			body, err := client.Get(path, query, &rsp)
+			if err != nil {
+				return fmt.Errorf("getting the list of instances: %w", err)
+			}
+
+			if output.Format(cmd) == output.FmtJSON {
+				fmt.Println(string(body))
+				return nil
+			}
+
+			if len(rsp.Results) == 0 {
+				fmt.Printf("you have no instances\n")
+				return nil
+			}
+
+			table := make([][]string, len(rsp.Results)+1)
+			table[0] = []string{"ID", "Name", "Status", "Flavor", "Addresses", "Created"}
+			for i, inst := range rsp.Results {
+				table[i+1] = []string{
+					inst.Id,
+					inst.Name,
+					inst.Status,
+					inst.Flavor.Id,
+					strings.Join(inst.addresses(), ", "),
+					inst.Created,
+				}
+			}
+			output.Table(table, output.Format(cmd))
+			return nil
+		},
+	}
+	cmdList.Flags().String("name", "", "Show instances with names containing the string")
+
+	var cmdShow = &cobra.Command{
+		Use:     "show <instance>",
+		Aliases: []string{"get"},
+		Short:   "Show instance details",
+		Args:    cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			path, id, err := instancePath(cmd, args[0])
+			if err != nil {
+				return err
+			}
+
+			var inst instanceInfo
+			body, err := client.Get(path, nil, &inst)
+			if err != nil {
+				return fmt.Errorf("getting instance details: %w", err)
+			}
+
+			if output.Format(cmd) == output.FmtJSON {
+				fmt.Println(string(body))
+				return nil
+			}
+
+			fmt.Printf(
+				"ID:\t   %s\nName:\t   %s\nStatus:\t   %s\nFlavor:\t   %s (%d vCPU, %d MiB RAM)\nCreated:   %s\n",
+				id,
+				inst.Name,
+				inst.Status,
+				inst.Flavor.Id,
+				inst.Flavor.Vcpus,
+				inst.Flavor.Ram,
+				inst.Created,
+			)
+			if inst.KeypairName != "" {
+				fmt.Printf("Keypair:   %s\n", inst.KeypairName)
+			}
+			if len(inst.Addresses) > 0 {
+				fmt.Println("Addresses:")
+				table := [][]string{}
+				for net, addrs := range inst.Addresses {
+					for _, a := range addrs {
+						table = append(table, []string{"\t" + net, a.Addr, a.Type})
+					}
+				}
+				slices.SortFunc(table, func(a, b []string) int { return strings.Compare(a[0], b[0]) })
+				output.Table(table, output.FmtHuman)
+			}
+			if len(inst.Volumes) > 0 {
+				fmt.Println("Volumes:")
+				for _, v := range inst.Volumes {
+					fmt.Printf("\t%s\n", v.Id)
+				}
+			}
+			if len(inst.SecurityGroups) > 0 {
+				fmt.Println("Security groups:")
+				for _, sg := range inst.SecurityGroups {
+					fmt.Printf("\t%s\n", sg.Name)
+				}
+			}
+			return nil
+		},
+	}
+
+	var cmdCreate = &cobra.Command{
+		Use:   "create",
+		Short: "Create new instance",
+		Long: `Create new instance with the boot volume from the image. Instance is
+connected to the network, specified with "--network" flag, or to the
+external network by default. The password of the user is read from the file,
+given with "--password-file" flag, or from GCORE_INSTANCE_PASSWORD env variable,
+so it doesn't show up in shell history and process list.`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			path, err := regionPath(cmd, "v2/instances")
+			if err != nil {
+				return err
+			}
+			req, err := parseInstanceCreate(cmd)
+			if err != nil {
+				return err
+			}
+
+			var tasks taskList
+			body, err := client.Post(path, req, &tasks)
+			if err != nil {
+				return fmt.Errorf("creating the instance: %w", err)
+			}
+			return handleTasks(cmd, body, tasks, func(finished []task) {
+				for _, t := range finished {
+					for _, id := range t.createdIds("instances") {
+						fmt.Printf("Instance %s created\n", id)
+					}
+				}
+			})
+		},
+	}
+	cmdCreate.Flags().String("name", "", "Instance name")
+	cmdCreate.Flags().String("flavor", "", "Flavor ID, like 'g1-standard-1-2'")
+	cmdCreate.Flags().String("image", "", "Boot image ID or name")
+	cmdCreate.Flags().Int("volume-size", 10, "Boot volume size, GiB")
+	cmdCreate.Flags().String("volume-type", "standard", "Boot volume type")
+	cmdCreate.Flags().String("network", "", "Network ID (default is the external network)")
+	cmdCreate.Flags().String("keypair", "", "SSH keypair ID or name")
+	cmdCreate.Flags().String("username", "", "Name of the user to create")
+	cmdCreate.Flags().String("password-file", "", "File with the password of the user ('-' means stdin)")
+	cmdCreate.MarkFlagRequired("name")
+	cmdCreate.MarkFlagRequired("flavor")
+	cmdCreate.MarkFlagRequired("image")
+
+	var cmdDelete = &cobra.Command{
+		Use:     "delete <instance>",
+		Aliases: []string{"rm"},
+		Short:   "Delete the instance",
+		Args:    cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			path, id, err := instancePath(cmd, args[0])
+			if err != nil {
+				return err
+			}
+			if !sure.AreYou(cmd, fmt.Sprintf("delete instance %s", id)) {
+				return e.ErrAborted
+			}
+
+			query := url.Values{}
+			if deleteFloatings, _ := cmd.Flags().GetBool("delete-floatings"); deleteFloatings {
+				query.Set("delete_floatings", "true")
+			}
+			var tasks taskList
+			body, err := client.Delete(path, query, &tasks)
+			if err != nil {
+				return fmt.Errorf("deleting the instance: %w", err)
+			}
+			return handleTasks(cmd, body, tasks, func([]task) {
+				fmt.Printf("Instance %s deleted\n", id)
+			})
+		},
+	}
+	cmdDelete.Flags().Bool("delete-floatings", false, "Delete floating IPs, assigned to the instance")
+
+	var cmdResize = &cobra.Command{
+		Use:   "resize <instance>",
+		Short: "Change the instance flavor",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			flavor, err := cmd.Flags().GetString("flavor")
+			if err != nil {
+				return err
+			}
+			path, id, err := instancePath(cmd, args[0])
+			if err != nil {
+				return err
+			}
+
+			var tasks taskList
+			body, err := client.Post(path+"/changeflavor", map[string]string{"flavor_id": flavor}, &tasks)
+			if err != nil {
+				return fmt.Errorf("resizing the instance: %w", err)
+			}
+			return handleTasks(cmd, body, tasks, func([]task) {
+				fmt.Printf("Instance %s resized to %s\n", id, flavor)
+			})
+		},
+	}
+	cmdResize.Flags().String("flavor", "", "New flavor ID")
+	cmdResize.MarkFlagRequired("flavor")
+
+	cmdInstance.AddCommand(
+		cmdList,
+		cmdShow,
+		cmdCreate,
+		cmdDelete,
+		instanceAction("start", "starting", "started", instanceStatusActive, false),
+		instanceAction("stop", "stopping", "stopped", instanceStatusShutoff, false),
+		instanceAction("reboot", "rebooting", "rebooted", instanceStatusActive, true),
+		cmdResize,
+	)
+	return cmdInstance
+}
+
+// instanceAction returns command for the power action, like "start".
+// Depending on the action, API returns either tasks or the instance, in the
+// latter case command waits for the instance to reach target status. With
+// restart set, the instance is in target status already, so it must leave
+// it first.
+func instanceAction(action, doing, done, target string, restart bool) *cobra.Command {
+	return &cobra.Command{
+		Use:   action + " <instance>",
+		Short: strings.ToUpper(action[:1]) + action[1:] + " the instance",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			path, id, err := instancePath(cmd, args[0])
+			if err != nil {
+				return err
+			}
+
+			var rsp struct {
+				taskList
+				instanceInfo
+			}
+			body, err := client.Post(path+"/"+action, nil, &rsp)
+			if err != nil {
+				return fmt.Errorf("%s the instance: %w", doing, err)
+			}
+			report := func([]task) {
+				fmt.Printf("Instance %s %s\n", id, done)
+			}
+			if len(rsp.Tasks) > 0 {
+				return handleTasks(cmd, body, rsp.taskList, report)
+			}
+
+			wait, err := cmd.Flags().GetBool("wait")
+			if err != nil {
+				return err
+			}
+			if wait {
+				if body, err = waitForInstance(cmd, path, id, target, restart); err != nil {
+					return err
+				}
+			}
+			if output.Format(cmd) == output.FmtJSON {
+				fmt.Println(string(body))
+				return nil
+			}
+			report(nil)
+			return nil
+		},
+	}
+}
+
+// waitForInstance polls the instance until it reaches the status, returning
+// the last instance details. With leave set, the status counts only after
+// the instance has been in another one, like ACTIVE after REBOOT.
+func waitForInstance(cmd *cobra.Command, path, id, status string, leave bool) ([]byte, error) {
+	timeout, err := cmd.Flags().GetDuration("wait-timeout")
+	if err != nil {
+		return nil, err
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	spinner := progress.StartSpinner(progress.Out(), fmt.Sprintf("Waiting for instance %s to become %s", id, status))
+	defer spinner.Stop()
+
+	var poller poll.Poller
+	left := !leave
+	for {
+		var inst instanceInfo
+		body, err := client.Do(httpcache.Bypass(ctx), http.MethodGet, path, nil, nil, &inst)
+		if err != nil {
+			return nil, poll.Err(ctx, fmt.Errorf("getting instance status: %w", err))
+		}
+		switch {
+		case inst.Status == instanceStatusError:
+			return nil, errors.New("instance is in error state")
+		case inst.Status != status:
+			left = true
+		case left:
+			return body, nil
+		}
+
+		if err := poller.Wait(ctx); err != nil {
+			return nil, err
+		}
+	}
+}
+
+// instancePath resolves the instance by ID or name, returning its API path
+// and ID
+func instancePath(cmd *cobra.Command, arg string) (string, string, error) {
+	return resourcePath(cmd, "v1/instances", "instance", arg)
+}
+
+func parseInstanceCreate(cmd *cobra.Command) (*instanceCreate, error) {
+	flags := cmd.Flags()
+	var req instanceCreate
+	var err error
+	if req.Name, err = flags.GetString("name"); err != nil {
+		return nil, err
+	}
+	if req.Flavor, err = flags.GetString("flavor"); err != nil {
+		return nil, err
+	}
+	keypair, err := flags.GetString("keypair")
+	if err != nil {
+		return nil, err
+	}
+	if keypair != "" {
+		kp, err := resolveKeypair(cmd, keypair)
+		if err != nil {
+			return nil, err
+		}
+		req.KeypairName = kp.Name
+	}
+	if req.Username, err = flags.GetString("username"); err != nil {
+		return nil, err
+	}
+	if req.Password, err = readPassword(cmd); err != nil {
+		return nil, err
+	}
+
+	vol := instanceVolume{Source: "image", BootIndex: 0}
+	image, err := flags.GetString("image")
+	if err != nil {
+		return nil, err
+	}
+	if _, vol.ImageId, err = imagePath(cmd, image); err != nil {
+		return nil, err
+	}
+	if vol.Size, err = flags.GetInt("volume-size"); err != nil {
+		return nil, err
+	}
+	if vol.TypeName, err = flags.GetString("volume-type"); err != nil {
+		return nil, err
+	}
+	req.Volumes = []instanceVolume{vol}
+
+	network, err := flags.GetString("network")
+	if err != nil {
+		return nil, err
+	}
+	if network != "" {
+		req.Interfaces = []instanceInterface{{Type: "any_subnet", NetworkId: network}}
+	} else {
+		req.Interfaces = []instanceInterface{{Type: "external"}}
+	}
+	return &req, nil
+}
+
+// readPassword reads the user password from "--password-file" (with "-"
+// meaning stdin), falling back to GCORE_INSTANCE_PASSWORD env variable.
+// Trailing newline of the file is dropped.
+func readPassword(cmd *cobra.Command) (string, error) {
+	file, err := cmd.Flags().GetString("password-file")
+	if err != nil {
+		return "", err
+	}
+	if file == "" {
+		return os.Getenv("GCORE_INSTANCE_PASSWORD"), nil
+	}
+
+	var buf []byte
+	if file == "-" {
+		buf, err = io.ReadAll(os.Stdin)
+	} else {
+		buf, err = os.ReadFile(file)
+	}
+	if err != nil {
+		return "", fmt.Errorf("cannot read password: %w", err)
+	}
+	return strings.TrimRight(string(buf), "\r\n"), nil
+}
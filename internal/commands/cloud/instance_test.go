@@ -0,0 +1,56 @@
+package cloud
+
+import (
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/alecthomas/assert"
+	"github.com/spf13/cobra"
+)
+
+func TestReadPassword(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "password")
+	assert.NoError(t, os.WriteFile(file, []byte("s3cret\n"), 0600))
+
+	newCmd := func(args ...string) *cobra.Command {
+		cmd := &cobra.Command{}
+		cmd.Flags().String("password-file", "", "")
+		assert.NoError(t, cmd.ParseFlags(args))
+		return cmd
+	}
+
+	t.Setenv("GCORE_INSTANCE_PASSWORD", "from-env")
+
+	password, err := readPassword(newCmd("--password-file", file))
+	assert.NoError(t, err)
+	assert.Equal(t, "s3cret", password)
+
+	password, err = readPassword(newCmd())
+	assert.NoError(t, err)
+	assert.Equal(t, "from-env", password)
+
+	_, err = readPassword(newCmd("--password-file", file+".missing"))
+	assert.Error(t, err)
+}
+
+func TestWaitForInstanceReboot(t *testing.T) {
+	statuses := []string{"ACTIVE", "REBOOT", "ACTIVE"}
+	polls := 0
+	testClient(t, func(w http.ResponseWriter, r *http.Request) {
+		status := statuses[min(polls, len(statuses)-1)]
+		polls++
+		io.WriteString(w, `{"instance_id":"i1","status":"`+status+`"}`)
+	})
+
+	cmd := &cobra.Command{}
+	cmd.Flags().Duration("wait-timeout", time.Minute, "")
+
+	// instance, still active right after the reboot request, isn't rebooted yet
+	_, err := waitForInstance(cmd, "/v1/instances/1/2/i1", "i1", instanceStatusActive, true)
+	assert.NoError(t, err)
+	assert.Equal(t, 3, polls)
+}
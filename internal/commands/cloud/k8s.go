@@ -0,0 +1,451 @@
+package cloud
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"os"
+	"strconv"
+
+	"github.com/spf13/cobra"
+
+	e "github.com/G-core/gcore-cli/internal/errors"
+	"github.com/G-core/gcore-cli/internal/httpcache"
+	"github.com/G-core/gcore-cli/internal/human"
+	"github.com/G-core/gcore-cli/internal/output"
+	"github.com/G-core/gcore-cli/internal/sure"
+)
+
+type k8sClusterInfo struct {
+	Name      string        `json:"name"`
+	Status    string        `json:"status"`
+	Version   string        `json:"version"`
+	Keypair   string        `json:"keypair"`
+	CreatedAt string        `json:"created_at"`
+	Pools     []k8sPoolInfo `json:"pools"`
+}
+
+type k8sPoolInfo struct {
+	Name           string `json:"name"`
+	FlavorId       string `json:"flavor_id"`
+	NodeCount      int    `json:"node_count"`
+	MinNodeCount   int    `json:"min_node_count"`
+	MaxNodeCount   int    `json:"max_node_count"`
+	BootVolumeSize int    `json:"boot_volume_size,omitempty"`
+	Status         string `json:"status,omitempty"`
+}
+
+// k8sClusterView is the cluster with its pools, shown by "k8s cluster show"
+// command
+type k8sClusterView struct {
+	Name    string
+	Status  string
+	Version string
+	Keypair string
+	Created string
+	Pools   []k8sPoolRow
+}
+
+type k8sPoolRow struct {
+	Name   string
+	Flavor string
+	Nodes  int
+	Min    int
+	Max    int
+	Status string
+}
+
+func k8s() *cobra.Command {
+	var cmdK8s = &cobra.Command{
+		Use:     "k8s <subcommand>",
+		Aliases: []string{"kubernetes"},
+		Short:   "Managed Kubernetes clusters management",
+		Long: `Managed Kubernetes clusters management. Clusters and their pools are
+specified by name.`,
+		Args: cobra.MinimumNArgs(1),
+	}
+
+	cmdK8s.AddCommand(k8sCluster(), k8sPool(), k8sKubeconfig())
+	return cmdK8s
+}
+
+func k8sCluster() *cobra.Command {
+	var cmdCluster = &cobra.Command{
+		Use:     "cluster <subcommand>",
+		Aliases: []string{"clusters"},
+		Short:   "Kubernetes clusters management",
+		Args:    cobra.MinimumNArgs(1),
+	}
+
+	var cmdList = &cobra.Command{
+		Use:     "list",
+		Aliases: []string{"ls"},
+		Short:   "Show list of clusters",
+		Args:    cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			path, err := regionPath(cmd, "v2/k8s/clusters")
+			if err != nil {
+				return err
+			}
+			var rsp list[k8sClusterInfo]
+			body, err := client.Get(path, nil, &rsp)
+			if err != nil {
+				return fmt.Errorf("getting the list of clusters: %w", err)
+			}
+
+			if output.Format(cmd) == output.FmtJSON {
+				fmt.Println(string(body))
+				return nil
+			}
+
+			if len(rsp.Results) == 0 {
+				fmt.Printf("you have no clusters\n")
+				return nil
+			}
+
+			table := make([][]string, len(rsp.Results)+1)
+			table[0] = []string{"Name", "Status", "Version", "Pools", "Nodes", "Created"}
+			for i, c := range rsp.Results {
+				nodes := 0
+				for _, p := range c.Pools {
+					nodes += p.NodeCount
+				}
+				table[i+1] = []string{
+					c.Name,
+					c.Status,
+					c.Version,
+					strconv.Itoa(len(c.Pools)),
+					strconv.Itoa(nodes),
+					c.CreatedAt,
+				}
+			}
+			output.Table(table, output.Format(cmd))
+			return nil
+		},
+	}
+
+	var cmdShow = &cobra.Command{
+		Use:     "show <cluster>",
+		Aliases: []string{"get"},
+		Short:   "Show cluster details with pools",
+		Args:    cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			path, err := regionPath(cmd, "v2/k8s/clusters", args[0])
+			if err != nil {
+				return err
+			}
+			var c k8sClusterInfo
+			body, err := client.Get(path, nil, &c)
+			if err != nil {
+				return fmt.Errorf("getting cluster details: %w", err)
+			}
+
+			if output.Format(cmd) == output.FmtJSON {
+				fmt.Println(string(body))
+				return nil
+			}
+
+			view := k8sClusterView{
+				Name:    c.Name,
+				Status:  c.Status,
+				Version: c.Version,
+				Keypair: c.Keypair,
+				Created: c.CreatedAt,
+			}
+			for _, p := range c.Pools {
+				view.Pools = append(view.Pools, k8sPoolRow{
+					Name:   p.Name,
+					Flavor: p.FlavorId,
+					Nodes:  p.NodeCount,
+					Min:    p.MinNodeCount,
+					Max:    p.MaxNodeCount,
+					Status: p.Status,
+				})
+			}
+			str, err := human.Marshal(&view, &human.MarshalOpt{
+				Sections: []*human.MarshalSection{{FieldName: "Pools", HideIfEmpty: true}},
+			})
+			if err != nil {
+				return err
+			}
+			fmt.Println(str)
+			return nil
+		},
+	}
+
+	var cmdCreate = &cobra.Command{
+		Use:   "create <name>",
+		Short: "Create new cluster",
+		Long: `Create new cluster with one pool of worker nodes. Pool is autoscaled
+between "--nodes" and "--max-nodes" nodes. More pools can be added later.`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			flags := cmd.Flags()
+			version, err := flags.GetString("version")
+			if err != nil {
+				return err
+			}
+			keypair, err := flags.GetString("keypair")
+			if err != nil {
+				return err
+			}
+			pool := k8sPoolInfo{}
+			if pool.Name, err = flags.GetString("pool"); err != nil {
+				return err
+			}
+			if pool.FlavorId, err = flags.GetString("flavor"); err != nil {
+				return err
+			}
+			if pool.MinNodeCount, err = flags.GetInt("nodes"); err != nil {
+				return err
+			}
+			if pool.MaxNodeCount, err = flags.GetInt("max-nodes"); err != nil {
+				return err
+			}
+			if pool.BootVolumeSize, err = flags.GetInt("volume-size"); err != nil {
+				return err
+			}
+			if pool.MaxNodeCount < pool.MinNodeCount {
+				pool.MaxNodeCount = pool.MinNodeCount
+			}
+
+			kp, err := resolveKeypair(cmd, keypair)
+			if err != nil {
+				return err
+			}
+			path, err := regionPath(cmd, "v2/k8s/clusters")
+			if err != nil {
+				return err
+			}
+
+			req := map[string]any{
+				"name":    args[0],
+				"keypair": kp.Name,
+				"version": version,
+				"pools": []map[string]any{{
+					"name":             pool.Name,
+					"flavor_id":        pool.FlavorId,
+					"min_node_count":   pool.MinNodeCount,
+					"max_node_count":   pool.MaxNodeCount,
+					"boot_volume_size": pool.BootVolumeSize,
+				}},
+			}
+			var tasks taskList
+			body, err := client.Post(path, req, &tasks)
+			if err != nil {
+				return fmt.Errorf("creating the cluster: %w", err)
+			}
+			return handleTasks(cmd, body, tasks, func([]task) {
+				fmt.Printf("Cluster %s created, get its config with \"cloud k8s kubeconfig %s --merge\"\n", args[0], args[0])
+			})
+		},
+	}
+	cmdCreate.Flags().String("version", "", "Kubernetes version, like 'v1.31.1'")
+	cmdCreate.Flags().String("keypair", "", "SSH keypair ID or name for the nodes")
+	cmdCreate.Flags().String("pool", "pool-1", "Name of the pool")
+	cmdCreate.Flags().String("flavor", "", "Flavor ID of the nodes, like 'g1-standard-2-4'")
+	cmdCreate.Flags().Int("nodes", 1, "Minimal number of nodes")
+	cmdCreate.Flags().Int("max-nodes", 0, "Maximal number of nodes (default is the minimal number)")
+	cmdCreate.Flags().Int("volume-size", 10, "Boot volume size of the nodes, GiB")
+	cmdCreate.MarkFlagRequired("version")
+	cmdCreate.MarkFlagRequired("keypair")
+	cmdCreate.MarkFlagRequired("flavor")
+
+	var cmdDelete = &cobra.Command{
+		Use:     "delete <cluster>",
+		Aliases: []string{"rm"},
+		Short:   "Delete the cluster",
+		Args:    cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			path, err := regionPath(cmd, "v2/k8s/clusters", args[0])
+			if err != nil {
+				return err
+			}
+			if !sure.AreYou(cmd, fmt.Sprintf("delete cluster %s", args[0])) {
+				return e.ErrAborted
+			}
+
+			var tasks taskList
+			body, err := client.Delete(path, nil, &tasks)
+			if err != nil {
+				return fmt.Errorf("deleting the cluster: %w", err)
+			}
+			return handleTasks(cmd, body, tasks, func([]task) {
+				fmt.Printf("Cluster %s deleted\n", args[0])
+			})
+		},
+	}
+
+	cmdCluster.AddCommand(cmdList, cmdShow, cmdCreate, cmdDelete)
+	return cmdCluster
+}
+
+func k8sPool() *cobra.Command {
+	var cmdPool = &cobra.Command{
+		Use:     "pool <subcommand>",
+		Aliases: []string{"pools"},
+		Short:   "Kubernetes cluster pools management",
+		Args:    cobra.MinimumNArgs(1),
+	}
+
+	var cmdList = &cobra.Command{
+		Use:     "list <cluster>",
+		Aliases: []string{"ls"},
+		Short:   "Show pools of the cluster",
+		Args:    cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			path, err := regionPath(cmd, "v2/k8s/clusters", args[0], "pools")
+			if err != nil {
+				return err
+			}
+			var rsp list[k8sPoolInfo]
+			body, err := client.Get(path, nil, &rsp)
+			if err != nil {
+				return fmt.Errorf("getting the list of pools: %w", err)
+			}
+
+			if output.Format(cmd) == output.FmtJSON {
+				fmt.Println(string(body))
+				return nil
+			}
+
+			if len(rsp.Results) == 0 {
+				fmt.Printf("cluster has no pools\n")
+				return nil
+			}
+
+			table := make([][]string, len(rsp.Results)+1)
+			table[0] = []string{"Name", "Flavor", "Nodes", "Min", "Max", "Status"}
+			for i, p := range rsp.Results {
+				table[i+1] = []string{
+					p.Name,
+					p.FlavorId,
+					strconv.Itoa(p.NodeCount),
+					strconv.Itoa(p.MinNodeCount),
+					strconv.Itoa(p.MaxNodeCount),
+					p.Status,
+				}
+			}
+			output.Table(table, output.Format(cmd))
+			return nil
+		},
+	}
+
+	var cmdScale = &cobra.Command{
+		Use:   "scale <cluster> <pool>",
+		Short: "Change the number of pool nodes",
+		Long: `Change the number of pool nodes. "--nodes" flag sets current number of
+nodes, "--min" and "--max" flags set autoscaling limits.`,
+		Args: cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			flags := cmd.Flags()
+			path, err := regionPath(cmd, "v2/k8s/clusters", args[0], "pools", args[1])
+			if err != nil {
+				return err
+			}
+
+			if flags.Changed("min") || flags.Changed("max") {
+				req := map[string]int{}
+				for flag, field := range map[string]string{"min": "min_node_count", "max": "max_node_count"} {
+					if flags.Changed(flag) {
+						n, err := flags.GetInt(flag)
+						if err != nil {
+							return err
+						}
+						req[field] = n
+					}
+				}
+				var pool k8sPoolInfo
+				body, err := client.Patch(path, req, &pool)
+				if err != nil {
+					return fmt.Errorf("changing the pool: %w", err)
+				}
+				if !flags.Changed("nodes") {
+					if output.Format(cmd) == output.FmtJSON {
+						fmt.Println(string(body))
+						return nil
+					}
+					fmt.Printf("Pool %s scales between %d and %d nodes\n", args[1], pool.MinNodeCount, pool.MaxNodeCount)
+					return nil
+				}
+			}
+
+			nodes, err := flags.GetInt("nodes")
+			if err != nil {
+				return err
+			}
+			var tasks taskList
+			body, err := client.Post(path+"/resize", map[string]int{"node_count": nodes}, &tasks)
+			if err != nil {
+				return fmt.Errorf("resizing the pool: %w", err)
+			}
+			return handleTasks(cmd, body, tasks, func([]task) {
+				fmt.Printf("Pool %s resized to %d nodes\n", args[1], nodes)
+			})
+		},
+	}
+	cmdScale.Flags().Int("nodes", 0, "Number of nodes")
+	cmdScale.Flags().Int("min", 0, "Minimal number of nodes for autoscaling")
+	cmdScale.Flags().Int("max", 0, "Maximal number of nodes for autoscaling")
+	cmdScale.MarkFlagsOneRequired("nodes", "min", "max")
+
+	cmdPool.AddCommand(cmdList, cmdScale)
+	return cmdPool
+}
+
+func k8sKubeconfig() *cobra.Command {
+	var cmdKubeconfig = &cobra.Command{
+		Use:   "kubeconfig <cluster>",
+		Short: "Get kubectl config of the cluster",
+		Long: `Get kubectl config of the cluster. The config is printed, unless "--merge"
+flag is specified, then it is merged into kubectl config file (first file
+of KUBECONFIG env variable or ~/.kube/config). The file is backed up before
+the change, and cluster, user and context names, colliding with the existing
+ones, get numeric suffixes.`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			merge, err := cmd.Flags().GetBool("merge")
+			if err != nil {
+				return err
+			}
+			path, err := regionPath(cmd, "v2/k8s/clusters", args[0], "config")
+			if err != nil {
+				return err
+			}
+			var rsp struct {
+				Config string `json:"config"`
+			}
+			// kubeconfig contains credentials, so it is never cached
+			_, err = client.Do(httpcache.Bypass(context.Background()), http.MethodGet, path, nil, nil, &rsp)
+			if err != nil {
+				return fmt.Errorf("getting the cluster config: %w", err)
+			}
+
+			if !merge {
+				fmt.Print(rsp.Config)
+				return nil
+			}
+
+			file, err := kubeconfigPath()
+			if err != nil {
+				return err
+			}
+			context, backup, err := mergeKubeconfigFile(file, []byte(rsp.Config))
+			if err != nil {
+				return &e.CliError{
+					Err:  fmt.Errorf("merging the config: %w", err),
+					Hint: `Get the config without "--merge" flag and merge it manually`,
+					Code: 1,
+				}
+			}
+			if backup != "" {
+				fmt.Fprintf(os.Stderr, "%s backed up to %s\n", file, backup)
+			}
+			fmt.Printf("Cluster %s config merged into %s, use it with \"kubectl config use-context %s\"\n", args[0], file, context)
+			return nil
+		},
+	}
+	cmdKubeconfig.Flags().Bool("merge", false, "Merge the config into kubectl config file")
+
+	return cmdKubeconfig
+}
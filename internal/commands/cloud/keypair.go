@@ -0,0 +1,334 @@
+package cloud
+
+import (
+	"bytes"
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/G-core/gcore-cli/internal/apiclient"
+	e "github.com/G-core/gcore-cli/internal/errors"
+	"github.com/G-core/gcore-cli/internal/output"
+	"github.com/G-core/gcore-cli/internal/sure"
+)
+
+type keypairInfo struct {
+	Id          string `json:"sshkey_id"`
+	Name        string `json:"sshkey_name"`
+	PublicKey   string `json:"public_key"`
+	Fingerprint string `json:"fingerprint"`
+	State       string `json:"state"`
+	CreatedAt   string `json:"created_at"`
+}
+
+// sshKeyFiles are public keys, tried in order by "keypair import" command,
+// when the file is not specified
+var sshKeyFiles = []string{"id_ed25519.pub", "id_ecdsa.pub", "id_rsa.pub"}
+
+func keypair() *cobra.Command {
+	var cmdKeypair = &cobra.Command{
+		Use:     "keypair <subcommand>",
+		Aliases: []string{"keypairs", "sshkey"},
+		Short:   "SSH keypairs management",
+		Long: `SSH keypairs management. Keypairs can be specified by ID or name, and
+instances are created with the keypair name ("--keypair" flag).`,
+		Args: cobra.MinimumNArgs(1),
+	}
+
+	var cmdList = &cobra.Command{
+		Use:     "list",
+		Aliases: []string{"ls"},
+		Short:   "Show list of keypairs",
+		Args:    cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			keypairs, body, err := listKeypairs(cmd)
+			if err != nil {
+				return err
+			}
+
+			if output.Format(cmd) == output.FmtJSON {
+				fmt.Println(string(body))
+				return nil
+			}
+
+			if len(keypairs) == 0 {
+				fmt.Printf("you have no keypairs\n")
+				return nil
+			}
+
+			table := make([][]string, len(keypairs)+1)
+			table[0] = []string{"ID", "Name", "Fingerprint", "Created"}
+			for i, k := range keypairs {
+				table[i+1] = []string{k.Id, k.Name, k.Fingerprint, k.CreatedAt}
+			}
+			output.Table(table, output.Format(cmd))
+			return nil
+		},
+	}
+
+	var cmdCreate = &cobra.Command{
+		Use:   "create <name>",
+		Short: "Generate new keypair",
+		Long: `Generate new ed25519 keypair locally and upload its public key. Private
+key is saved to "~/.ssh/<name>" (or the file, specified with "--file" flag)
+with 0600 permissions, public key is saved next to it with ".pub" suffix.
+Existing files are never overwritten.`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			file, err := cmd.Flags().GetString("file")
+			if err != nil {
+				return err
+			}
+			if file == "" {
+				home, err := os.UserHomeDir()
+				if err != nil {
+					return err
+				}
+				file = filepath.Join(home, ".ssh", args[0])
+			}
+
+			pub, priv, err := generateKeypair(args[0])
+			if err != nil {
+				return fmt.Errorf("generating the keypair: %w", err)
+			}
+			if err := saveKeypair(file, pub, priv); err != nil {
+				return fmt.Errorf("saving the keypair: %w", err)
+			}
+
+			body, err := uploadKeypair(cmd, args[0], pub)
+			if err != nil {
+				os.Remove(file)
+				os.Remove(file + ".pub")
+				return err
+			}
+
+			if output.Format(cmd) == output.FmtJSON {
+				fmt.Println(string(body))
+				return nil
+			}
+			fmt.Printf("Keypair %s created, private key saved to %s\n", args[0], file)
+			return nil
+		},
+	}
+	cmdCreate.Flags().String("file", "", "Private key file (default is ~/.ssh/<name>)")
+	cmdCreate.MarkFlagFilename("file")
+
+	var cmdImport = &cobra.Command{
+		Use:   "import <name>",
+		Short: "Import existing public key",
+		Long: `Import existing public key. Without "--file" flag, the first found of
+~/.ssh/id_ed25519.pub, ~/.ssh/id_ecdsa.pub, ~/.ssh/id_rsa.pub and other
+~/.ssh/id_*.pub files is used.`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			file, err := cmd.Flags().GetString("file")
+			if err != nil {
+				return err
+			}
+			if file == "" {
+				if file, err = defaultPublicKey(); err != nil {
+					return err
+				}
+			}
+
+			buf, err := os.ReadFile(file)
+			if err != nil {
+				return fmt.Errorf("cannot read public key: %w", err)
+			}
+			pub := strings.TrimSpace(string(buf))
+			if !strings.HasPrefix(pub, "ssh-") && !strings.HasPrefix(pub, "ecdsa-") {
+				return fmt.Errorf("%s is not an OpenSSH public key", file)
+			}
+
+			body, err := uploadKeypair(cmd, args[0], pub)
+			if err != nil {
+				return err
+			}
+
+			if output.Format(cmd) == output.FmtJSON {
+				fmt.Println(string(body))
+				return nil
+			}
+			fmt.Printf("Keypair %s imported from %s\n", args[0], file)
+			return nil
+		},
+	}
+	cmdImport.Flags().String("file", "", "Public key file (default is ~/.ssh/id_*.pub)")
+	cmdImport.MarkFlagFilename("file", "pub")
+
+	var cmdDelete = &cobra.Command{
+		Use:     "delete <keypair>",
+		Aliases: []string{"rm"},
+		Short:   "Delete the keypair",
+		Args:    cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			kp, err := resolveKeypair(cmd, args[0])
+			if err != nil {
+				return err
+			}
+			if !sure.AreYou(cmd, fmt.Sprintf("delete keypair %s", kp.Name)) {
+				return e.ErrAborted
+			}
+
+			path, err := regionPath(cmd, "v1/keypairs", kp.Id)
+			if err != nil {
+				return err
+			}
+			if _, err := client.Delete(path, nil, nil); err != nil {
+				return fmt.Errorf("deleting the keypair: %w", err)
+			}
+			fmt.Printf("Keypair %s deleted\n", kp.Name)
+			return nil
+		},
+	}
+
+	cmdKeypair.AddCommand(cmdList, cmdCreate, cmdImport, cmdDelete)
+	return cmdKeypair
+}
+
+func listKeypairs(cmd *cobra.Command) ([]keypairInfo, []byte, error) {
+	path, err := regionPath(cmd, "v1/keypairs")
+	if err != nil {
+		return nil, nil, err
+	}
+	var rsp list[keypairInfo]
+	body, err := client.Get(path, nil, &rsp)
+	if err != nil {
+		return nil, nil, fmt.Errorf("getting the list of keypairs: %w", err)
+	}
+	return rsp.Results, body, nil
+}
+
+// resolveKeypair finds the keypair by ID or name
+func resolveKeypair(cmd *cobra.Command, arg string) (*keypairInfo, error) {
+	keypairs, _, err := listKeypairs(cmd)
+	if err != nil {
+		return nil, err
+	}
+	var names []string
+	for _, k := range keypairs {
+		if k.Id == arg || k.Name == arg {
+			return &k, nil
+		}
+		names = append(names, k.Name)
+	}
+	return nil, apiclient.NotFound("keypair", arg, names)
+}
+
+func uploadKeypair(cmd *cobra.Command, name, pub string) ([]byte, error) {
+	path, err := regionPath(cmd, "v1/keypairs")
+	if err != nil {
+		return nil, err
+	}
+	body, err := client.Post(path, map[string]string{"sshkey_name": name, "public_key": pub}, nil)
+	if err != nil {
+		return nil, fmt.Errorf("uploading the public key: %w", err)
+	}
+	return body, nil
+}
+
+func defaultPublicKey() (string, error) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", err
+	}
+	dir := filepath.Join(home, ".ssh")
+	found, _ := filepath.Glob(filepath.Join(dir, "id_*.pub"))
+	for _, name := range sshKeyFiles {
+		if slices.Contains(found, filepath.Join(dir, name)) {
+			return filepath.Join(dir, name), nil
+		}
+	}
+	if len(found) > 0 {
+		return found[0], nil
+	}
+	return "", &e.CliError{
+		Err:  fmt.Errorf("no public keys found in %s", dir),
+		Hint: `Specify the key with "--file" flag, or generate new one with "cloud keypair create" command`,
+		Code: 1,
+	}
+}
+
+// generateKeypair returns new ed25519 key in OpenSSH formats: public key
+// line and PEM-encoded private key
+func generateKeypair(comment string) (string, []byte, error) {
+	pub, priv, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		return "", nil, err
+	}
+
+	var pubBlob bytes.Buffer
+	sshString(&pubBlob, []byte("ssh-ed25519"))
+	sshString(&pubBlob, pub)
+
+	// "openssh-key-v1" format, as described in OpenSSH PROTOCOL.key
+	var check [4]byte
+	if _, err := rand.Read(check[:]); err != nil {
+		return "", nil, err
+	}
+	var keys bytes.Buffer
+	keys.Write(check[:])
+	keys.Write(check[:])
+	sshString(&keys, []byte("ssh-ed25519"))
+	sshString(&keys, pub)
+	sshString(&keys, priv)
+	sshString(&keys, []byte(comment))
+	for i := byte(1); keys.Len()%8 != 0; i++ {
+		keys.WriteByte(i)
+	}
+
+	var buf bytes.Buffer
+	buf.WriteString("openssh-key-v1\x00")
+	sshString(&buf, []byte("none"))
+	sshString(&buf, []byte("none"))
+	sshString(&buf, nil)
+	binary.Write(&buf, binary.BigEndian, uint32(1))
+	sshString(&buf, pubBlob.Bytes())
+	sshString(&buf, keys.Bytes())
+
+	pubLine := "ssh-ed25519 " + base64.StdEncoding.EncodeToString(pubBlob.Bytes()) + " " + comment
+	privPem := pem.EncodeToMemory(&pem.Block{Type: "OPENSSH PRIVATE KEY", Bytes: buf.Bytes()})
+	return pubLine, privPem, nil
+}
+
+// sshString writes SSH wire format string: 32-bit length and data
+func sshString(buf *bytes.Buffer, s []byte) {
+	binary.Write(buf, binary.BigEndian, uint32(len(s)))
+	buf.Write(s)
+}
+
+// saveKeypair writes private key with 0600 permissions and public key into
+// file with ".pub" suffix, failing if any of them exists
+func saveKeypair(file, pub string, priv []byte) error {
+	if err := os.MkdirAll(filepath.Dir(file), 0700); err != nil {
+		return err
+	}
+	if _, err := os.Stat(file + ".pub"); err == nil {
+		return fmt.Errorf("%s already exists", file+".pub")
+	}
+	f, err := os.OpenFile(file, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0600)
+	if err != nil {
+		if errors.Is(err, os.ErrExist) {
+			return fmt.Errorf("%s already exists", file)
+		}
+		return err
+	}
+	if _, err := f.Write(priv); err != nil {
+		f.Close()
+		return err
+	}
+	if err := f.Close(); err != nil {
+		return err
+	}
+	return os.WriteFile(file+".pub", []byte(pub+"\n"), 0644)
+}
@@ -0,0 +1,166 @@
+package cloud
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strconv"
+	"time"
+
+	"go.yaml.in/yaml/v3"
+)
+
+// kubeconfig is kubectl config file. Only names and references are parsed,
+// the rest of the content is kept as is.
+type kubeconfig struct {
+	APIVersion     string            `yaml:"apiVersion"`
+	Kind           string            `yaml:"kind"`
+	Clusters       []kubeconfigEntry `yaml:"clusters"`
+	Users          []kubeconfigEntry `yaml:"users"`
+	Contexts       []kubeconfigEntry `yaml:"contexts"`
+	CurrentContext string            `yaml:"current-context"`
+	Rest           map[string]any    `yaml:",inline"`
+}
+
+type kubeconfigEntry struct {
+	Name string         `yaml:"name"`
+	Rest map[string]any `yaml:",inline"`
+}
+
+// kubeconfigPath returns the config file, used by kubectl
+func kubeconfigPath() (string, error) {
+	if env := os.Getenv("KUBECONFIG"); env != "" {
+		return filepath.SplitList(env)[0], nil
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(home, ".kube", "config"), nil
+}
+
+// mergeKubeconfigFile merges src config into the file, saving its backup
+// first. It returns name of the merged context and backup file, if any.
+func mergeKubeconfigFile(file string, src []byte) (string, string, error) {
+	var from kubeconfig
+	if err := yaml.Unmarshal(src, &from); err != nil {
+		return "", "", fmt.Errorf("cannot parse cluster config: %w", err)
+	}
+
+	var to kubeconfig
+	var backup string
+	buf, err := os.ReadFile(file)
+	switch {
+	case err == nil:
+		if err := yaml.Unmarshal(buf, &to); err != nil {
+			return "", "", fmt.Errorf("cannot parse %s: %w", file, err)
+		}
+		backup = file + ".bak-" + time.Now().Format("20060102150405")
+		if err := os.WriteFile(backup, buf, 0600); err != nil {
+			return "", "", fmt.Errorf("cannot save the backup: %w", err)
+		}
+	case os.IsNotExist(err):
+		to.APIVersion, to.Kind = "v1", "Config"
+		if err := os.MkdirAll(filepath.Dir(file), 0700); err != nil {
+			return "", "", err
+		}
+	default:
+		return "", "", err
+	}
+
+	contexts := mergeKubeconfig(&to, &from)
+	var out bytes.Buffer
+	enc := yaml.NewEncoder(&out)
+	enc.SetIndent(2)
+	if err := enc.Encode(&to); err != nil {
+		return "", "", err
+	}
+	if err := os.WriteFile(file, out.Bytes(), 0600); err != nil {
+		return "", "", err
+	}
+	var context string
+	if len(contexts) > 0 {
+		context = contexts[0]
+	}
+	return context, backup, nil
+}
+
+// mergeKubeconfig adds clusters, users and contexts of src to dst, returning
+// names of the added contexts. Entries, colliding with existing ones by name,
+// are renamed, unless they are the same, and references are updated.
+func mergeKubeconfig(dst, src *kubeconfig) []string {
+	clusters := mergeKubeconfigEntries(&dst.Clusters, src.Clusters)
+	users := mergeKubeconfigEntries(&dst.Users, src.Users)
+
+	for i, c := range src.Contexts {
+		ctx, ok := c.Rest["context"].(map[string]any)
+		if !ok {
+			continue
+		}
+		// context map is shared with src, so it is copied before renaming
+		renamed := map[string]any{}
+		for k, v := range ctx {
+			renamed[k] = v
+		}
+		// references to entries, not defined in src, are kept as is
+		if name, ok := renamed["cluster"].(string); ok {
+			if n, ok := clusters[name]; ok {
+				renamed["cluster"] = n
+			}
+		}
+		if name, ok := renamed["user"].(string); ok {
+			if n, ok := users[name]; ok {
+				renamed["user"] = n
+			}
+		}
+		rest := map[string]any{}
+		for k, v := range c.Rest {
+			rest[k] = v
+		}
+		rest["context"] = renamed
+		src.Contexts[i] = kubeconfigEntry{Name: c.Name, Rest: rest}
+	}
+	contexts := mergeKubeconfigEntries(&dst.Contexts, src.Contexts)
+
+	var names []string
+	for _, c := range src.Contexts {
+		names = append(names, contexts[c.Name])
+	}
+	if dst.CurrentContext == "" && len(names) > 0 {
+		dst.CurrentContext = names[0]
+	}
+	return names
+}
+
+// mergeKubeconfigEntries appends src entries to dst and returns the map of
+// src names to their names in dst
+func mergeKubeconfigEntries(dst *[]kubeconfigEntry, src []kubeconfigEntry) map[string]string {
+	names := map[string]string{}
+	for _, entry := range src {
+		name := entry.Name
+		for n := 1; ; n++ {
+			i := kubeconfigEntryIndex(*dst, name)
+			if i < 0 {
+				*dst = append(*dst, kubeconfigEntry{Name: name, Rest: entry.Rest})
+				break
+			}
+			if reflect.DeepEqual((*dst)[i].Rest, entry.Rest) {
+				break
+			}
+			name = entry.Name + "-" + strconv.Itoa(n)
+		}
+		names[entry.Name] = name
+	}
+	return names
+}
+
+func kubeconfigEntryIndex(entries []kubeconfigEntry, name string) int {
+	for i, e := range entries {
+		if e.Name == name {
+			return i
+		}
+	}
+	return -1
+}
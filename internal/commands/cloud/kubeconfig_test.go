@@ -0,0 +1,110 @@
+package cloud
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/alecthomas/assert"
+	"go.yaml.in/yaml/v3"
+)
+
+const testKubeconfig = `apiVersion: v1
+kind: Config
+clusters:
+- name: prod
+  cluster:
+    server: https://prod.example.com
+users:
+- name: admin
+  user:
+    token: prod-token
+contexts:
+- name: admin@prod
+  context:
+    cluster: prod
+    user: admin
+current-context: admin@prod
+`
+
+func TestMergeKubeconfigFile(t *testing.T) {
+	file := filepath.Join(t.TempDir(), ".kube", "config")
+
+	// new file is created as is
+	context, backup, err := mergeKubeconfigFile(file, []byte(testKubeconfig))
+	assert.NoError(t, err)
+	assert.Equal(t, "admin@prod", context)
+	assert.Equal(t, "", backup)
+	st, err := os.Stat(file)
+	assert.NoError(t, err)
+	assert.Equal(t, os.FileMode(0600), st.Mode().Perm())
+
+	// the same config is not duplicated, the file is backed up
+	before, err := os.ReadFile(file)
+	assert.NoError(t, err)
+	context, backup, err = mergeKubeconfigFile(file, []byte(testKubeconfig))
+	assert.NoError(t, err)
+	assert.Equal(t, "admin@prod", context)
+	buf, err := os.ReadFile(backup)
+	assert.NoError(t, err)
+	assert.Equal(t, string(before), string(buf))
+	buf, err = os.ReadFile(file)
+	assert.NoError(t, err)
+	assert.Equal(t, string(before), string(buf))
+
+	// colliding names are renamed, references are updated
+	other := `clusters:
+- name: prod
+  cluster:
+    server: https://other.example.com
+users:
+- name: admin
+  user:
+    token: prod-token
+contexts:
+- name: admin@prod
+  context:
+    cluster: prod
+    user: admin
+current-context: admin@prod
+`
+	context, _, err = mergeKubeconfigFile(file, []byte(other))
+	assert.NoError(t, err)
+	assert.Equal(t, "admin@prod-1", context)
+
+	buf, err = os.ReadFile(file)
+	assert.NoError(t, err)
+	var cfg kubeconfig
+	assert.NoError(t, yaml.Unmarshal(buf, &cfg))
+	assert.Equal(t, "admin@prod", cfg.CurrentContext)
+	assert.Equal(t, "v1", cfg.APIVersion)
+	assert.Equal(t, 2, len(cfg.Clusters))
+	assert.Equal(t, "prod-1", cfg.Clusters[1].Name)
+	assert.Equal(t, 1, len(cfg.Users))
+	assert.Equal(t, 2, len(cfg.Contexts))
+	assert.Equal(t, map[string]any{"cluster": "prod-1", "user": "admin"}, cfg.Contexts[1].Rest["context"])
+
+	_, _, err = mergeKubeconfigFile(file, []byte("clusters: {"))
+	assert.Error(t, err)
+}
+
+func TestMergeKubeconfigExternalRefs(t *testing.T) {
+	dst := kubeconfig{}
+	assert.NoError(t, yaml.Unmarshal([]byte(testKubeconfig), &dst))
+	src := kubeconfig{}
+	assert.NoError(t, yaml.Unmarshal([]byte(`clusters:
+- name: staging
+  cluster:
+    server: https://staging.example.com
+contexts:
+- name: admin@staging
+  context:
+    cluster: staging
+    user: admin
+`), &src))
+
+	// context refers to the user, defined only in dst
+	names := mergeKubeconfig(&dst, &src)
+	assert.Equal(t, []string{"admin@staging"}, names)
+	assert.Equal(t, map[string]any{"cluster": "staging", "user": "admin"}, dst.Contexts[1].Rest["context"])
+}
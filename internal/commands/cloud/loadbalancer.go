@@ -0,0 +1,781 @@
+package cloud
+
+import (
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	e "github.com/G-core/gcore-cli/internal/errors"
+	"github.com/G-core/gcore-cli/internal/human"
+	"github.com/G-core/gcore-cli/internal/output"
+	"github.com/G-core/gcore-cli/internal/sure"
+)
+
+type loadBalancerInfo struct {
+	Id                 string `json:"id"`
+	Name               string `json:"name"`
+	ProvisioningStatus string `json:"provisioning_status"`
+	OperatingStatus    string `json:"operating_status"`
+	VipAddress         string `json:"vip_address"`
+	CreatedAt          string `json:"created_at"`
+	Flavor             struct {
+		Name string `json:"flavor_name"`
+	} `json:"flavor"`
+	Listeners []struct {
+		Id string `json:"id"`
+	} `json:"listeners"`
+}
+
+type listenerInfo struct {
+	Id              string `json:"id"`
+	Name            string `json:"name"`
+	Protocol        string `json:"protocol"`
+	ProtocolPort    int    `json:"protocol_port"`
+	OperatingStatus string `json:"operating_status"`
+}
+
+type healthMonitorInfo struct {
+	Type          string  `json:"type"`
+	Delay         int     `json:"delay"`
+	Timeout       int     `json:"timeout"`
+	MaxRetries    int     `json:"max_retries"`
+	HttpMethod    *string `json:"http_method,omitempty"`
+	UrlPath       *string `json:"url_path,omitempty"`
+	ExpectedCodes *string `json:"expected_codes,omitempty"`
+}
+
+type poolMemberInfo struct {
+	Id              string `json:"id"`
+	Address         string `json:"address"`
+	ProtocolPort    int    `json:"protocol_port"`
+	Weight          int    `json:"weight"`
+	OperatingStatus string `json:"operating_status"`
+}
+
+type poolInfo struct {
+	Id            string             `json:"id"`
+	Name          string             `json:"name"`
+	Protocol      string             `json:"protocol"`
+	LbAlgorithm   string             `json:"lb_algorithm"`
+	Members       []poolMemberInfo   `json:"members"`
+	HealthMonitor *healthMonitorInfo `json:"healthmonitor"`
+	Listeners     []struct {
+		Id string `json:"id"`
+	} `json:"listeners"`
+}
+
+// String describes health monitor settings in one line
+func (h *healthMonitorInfo) String() string {
+	s := h.Type
+	if h.HttpMethod != nil && h.UrlPath != nil {
+		s += " " + *h.HttpMethod + " " + *h.UrlPath
+	}
+	s += fmt.Sprintf(" every %ds, timeout %ds, %d retries", h.Delay, h.Timeout, h.MaxRetries)
+	if h.ExpectedCodes != nil {
+		s += ", expecting " + *h.ExpectedCodes
+	}
+	return s
+}
+
+// loadBalancerView is the load balancer with its listeners, pools and pool
+// members, shown by "loadbalancer show" command
+type loadBalancerView struct {
+	ID              string
+	Name            string
+	Status          string
+	OperatingStatus string
+	Address         string
+	Flavor          string
+	Created         string
+	Listeners       []listenerRow
+	Pools           []poolRow
+	Members         []memberRow
+}
+
+type listenerRow struct {
+	ID       string
+	Name     string
+	Protocol string
+	Port     int
+	Status   string
+}
+
+type poolRow struct {
+	ID            string
+	Name          string
+	Protocol      string
+	Algorithm     string
+	Members       int
+	HealthMonitor string
+}
+
+type memberRow struct {
+	Pool    string
+	ID      string
+	Address string
+	Port    int
+	Weight  int
+	Status  string
+}
+
+func loadBalancer() *cobra.Command {
+	var cmdLb = &cobra.Command{
+		Use:     "loadbalancer <subcommand>",
+		Aliases: []string{"loadbalancers", "lb"},
+		Short:   "Load balancers management",
+		Long: `Load balancers management. Load balancers, listeners and pools can be
+specified by ID or name.`,
+		Args: cobra.MinimumNArgs(1),
+	}
+
+	var cmdList = &cobra.Command{
+		Use:     "list",
+		Aliases: []string{"ls"},
+		Short:   "Show list of load balancers",
+		Args:    cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			path, err := regionPath(cmd, "v1/loadbalancers")
+			if err != nil {
+				return err
+			}
+			var rsp list[loadBalancerInfo]
+			body, err := client.Get(path, nil, &rsp)
+			if err != nil {
+				return fmt.Errorf("getting the list of load balancers: %w", err)
+			}
+
+			if output.Format(cmd) == output.FmtJSON {
+				fmt.Println(string(body))
+				return nil
+			}
+
+			if len(rsp.Results) == 0 {
+				fmt.Printf("you have no load balancers\n")
+				return nil
+			}
+
+			table := make([][]string, len(rsp.Results)+1)
+			table[0] = []string{"ID", "Name", "Status", "Address", "Flavor", "Listeners"}
+			for i, lb := range rsp.Results {
+				table[i+1] = []string{
+					lb.Id,
+					lb.Name,
+					lb.ProvisioningStatus + "/" + lb.OperatingStatus,
+					lb.VipAddress,
+					lb.Flavor.Name,
+					strconv.Itoa(len(lb.Listeners)),
+				}
+			}
+			output.Table(table, output.Format(cmd))
+			return nil
+		},
+	}
+
+	var cmdShow = &cobra.Command{
+		Use:     "show <loadbalancer>",
+		Aliases: []string{"get"},
+		Short:   "Show load balancer details with listeners, pools and members",
+		Args:    cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			path, id, err := resourcePath(cmd, "v1/loadbalancers", "load balancer", args[0])
+			if err != nil {
+				return err
+			}
+			var lb loadBalancerInfo
+			body, err := client.Get(path, nil, &lb)
+			if err != nil {
+				return fmt.Errorf("getting load balancer details: %w", err)
+			}
+
+			if output.Format(cmd) == output.FmtJSON {
+				fmt.Println(string(body))
+				return nil
+			}
+
+			listeners, _, err := lbListeners(cmd, id)
+			if err != nil {
+				return err
+			}
+			pools, _, err := lbPools(cmd, id)
+			if err != nil {
+				return err
+			}
+
+			str, err := human.Marshal(newLoadBalancerView(&lb, listeners, pools), &human.MarshalOpt{
+				Sections: []*human.MarshalSection{
+					{FieldName: "Listeners", HideIfEmpty: true},
+					{FieldName: "Pools", HideIfEmpty: true},
+					{FieldName: "Members", Title: "Pool members", HideIfEmpty: true},
+				},
+			})
+			if err != nil {
+				return err
+			}
+			fmt.Println(str)
+			return nil
+		},
+	}
+
+	var cmdCreate = &cobra.Command{
+		Use:   "create",
+		Short: "Create new load balancer",
+		Long: `Create new load balancer. It gets the public address, unless the network
+is specified with "--network" flag.`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			name, err := cmd.Flags().GetString("name")
+			if err != nil {
+				return err
+			}
+			flavor, err := cmd.Flags().GetString("flavor")
+			if err != nil {
+				return err
+			}
+			network, err := cmd.Flags().GetString("network")
+			if err != nil {
+				return err
+			}
+			path, err := regionPath(cmd, "v1/loadbalancers")
+			if err != nil {
+				return err
+			}
+
+			req := map[string]string{"name": name}
+			if flavor != "" {
+				req["flavor"] = flavor
+			}
+			if network != "" {
+				_, netId, err := resourcePath(cmd, "v1/networks", "network", network)
+				if err != nil {
+					return err
+				}
+				req["vip_network_id"] = netId
+			}
+
+			var tasks taskList
+			body, err := client.Post(path, req, &tasks)
+			if err != nil {
+				return fmt.Errorf("creating the load balancer: %w", err)
+			}
+			return handleTasks(cmd, body, tasks, func(finished []task) {
+				for _, t := range finished {
+					for _, id := range t.createdIds("loadbalancers") {
+						fmt.Printf("Load balancer %s created\n", id)
+					}
+				}
+			})
+		},
+	}
+	cmdCreate.Flags().String("name", "", "Load balancer name")
+	cmdCreate.Flags().String("flavor", "", "Load balancer flavor, like 'lb1-1-2'")
+	cmdCreate.Flags().String("network", "", "Private network for the load balancer address")
+	cmdCreate.MarkFlagRequired("name")
+
+	cmdLb.AddCommand(
+		cmdList,
+		cmdShow,
+		cmdCreate,
+		deleteCommand("v1/loadbalancers", "load balancer"),
+		listener(),
+		pool(),
+		poolMember(),
+		healthMonitor(),
+	)
+	return cmdLb
+}
+
+func newLoadBalancerView(lb *loadBalancerInfo, listeners []listenerInfo, pools []poolInfo) *loadBalancerView {
+	view := &loadBalancerView{
+		ID:              lb.Id,
+		Name:            lb.Name,
+		Status:          lb.ProvisioningStatus,
+		OperatingStatus: lb.OperatingStatus,
+		Address:         lb.VipAddress,
+		Flavor:          lb.Flavor.Name,
+		Created:         lb.CreatedAt,
+	}
+	for _, l := range listeners {
+		view.Listeners = append(view.Listeners, listenerRow{
+			ID:       l.Id,
+			Name:     l.Name,
+			Protocol: l.Protocol,
+			Port:     l.ProtocolPort,
+			Status:   l.OperatingStatus,
+		})
+	}
+	for _, p := range pools {
+		row := poolRow{
+			ID:        p.Id,
+			Name:      p.Name,
+			Protocol:  p.Protocol,
+			Algorithm: p.LbAlgorithm,
+			Members:   len(p.Members),
+		}
+		if p.HealthMonitor != nil {
+			row.HealthMonitor = p.HealthMonitor.String()
+		}
+		view.Pools = append(view.Pools, row)
+		for _, m := range p.Members {
+			view.Members = append(view.Members, memberRow{
+				Pool:    p.Name,
+				ID:      m.Id,
+				Address: m.Address,
+				Port:    m.ProtocolPort,
+				Weight:  m.Weight,
+				Status:  m.OperatingStatus,
+			})
+		}
+	}
+	return view
+}
+
+func lbListeners(cmd *cobra.Command, lbId string) ([]listenerInfo, []byte, error) {
+	path, err := regionPath(cmd, "v1/lblisteners")
+	if err != nil {
+		return nil, nil, err
+	}
+	var rsp list[listenerInfo]
+	body, err := client.Get(path, url.Values{"loadbalancer_id": {lbId}}, &rsp)
+	if err != nil {
+		return nil, nil, fmt.Errorf("getting the list of listeners: %w", err)
+	}
+	return rsp.Results, body, nil
+}
+
+func lbPools(cmd *cobra.Command, lbId string) ([]poolInfo, []byte, error) {
+	path, err := regionPath(cmd, "v1/lbpools")
+	if err != nil {
+		return nil, nil, err
+	}
+	var rsp list[poolInfo]
+	body, err := client.Get(path, url.Values{"loadbalancer_id": {lbId}, "details": {"true"}}, &rsp)
+	if err != nil {
+		return nil, nil, fmt.Errorf("getting the list of pools: %w", err)
+	}
+	return rsp.Results, body, nil
+}
+
+func listener() *cobra.Command {
+	var cmdListener = &cobra.Command{
+		Use:     "listener <subcommand>",
+		Aliases: []string{"listeners"},
+		Short:   "Load balancer listeners management",
+		Args:    cobra.MinimumNArgs(1),
+	}
+
+	var cmdList = &cobra.Command{
+		Use:     "list <loadbalancer>",
+		Aliases: []string{"ls"},
+		Short:   "Show listeners of the load balancer",
+		Args:    cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			_, lbId, err := resourcePath(cmd, "v1/loadbalancers", "load balancer", args[0])
+			if err != nil {
+				return err
+			}
+			listeners, body, err := lbListeners(cmd, lbId)
+			if err != nil {
+				return err
+			}
+
+			if output.Format(cmd) == output.FmtJSON {
+				fmt.Println(string(body))
+				return nil
+			}
+
+			if len(listeners) == 0 {
+				fmt.Printf("load balancer has no listeners\n")
+				return nil
+			}
+
+			table := make([][]string, len(listeners)+1)
+			table[0] = []string{"ID", "Name", "Protocol", "Port", "Status"}
+			for i, l := range listeners {
+				table[i+1] = []string{l.Id, l.Name, l.Protocol, strconv.Itoa(l.ProtocolPort), l.OperatingStatus}
+			}
+			output.Table(table, output.Format(cmd))
+			return nil
+		},
+	}
+
+	var cmdCreate = &cobra.Command{
+		Use:   "create <loadbalancer>",
+		Short: "Add the listener to the load balancer",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			flags := cmd.Flags()
+			name, err := flags.GetString("name")
+			if err != nil {
+				return err
+			}
+			protocol, err := flags.GetString("protocol")
+			if err != nil {
+				return err
+			}
+			port, err := flags.GetInt("port")
+			if err != nil {
+				return err
+			}
+			_, lbId, err := resourcePath(cmd, "v1/loadbalancers", "load balancer", args[0])
+			if err != nil {
+				return err
+			}
+			path, err := regionPath(cmd, "v1/lblisteners")
+			if err != nil {
+				return err
+			}
+
+			req := map[string]any{
+				"loadbalancer_id": lbId,
+				"name":            name,
+				"protocol":        strings.ToUpper(protocol),
+				"protocol_port":   port,
+			}
+			var tasks taskList
+			body, err := client.Post(path, req, &tasks)
+			if err != nil {
+				return fmt.Errorf("creating the listener: %w", err)
+			}
+			return handleTasks(cmd, body, tasks, func(finished []task) {
+				for _, t := range finished {
+					for _, id := range t.createdIds("listeners") {
+						fmt.Printf("Listener %s created\n", id)
+					}
+				}
+			})
+		},
+	}
+	cmdCreate.Flags().String("name", "", "Listener name")
+	cmdCreate.Flags().String("protocol", "HTTP", "Protocol ('HTTP', 'HTTPS', 'TCP', 'UDP' etc.)")
+	cmdCreate.Flags().Int("port", 80, "Port to listen on")
+	cmdCreate.MarkFlagRequired("name")
+
+	cmdListener.AddCommand(cmdList, cmdCreate, deleteCommand("v1/lblisteners", "listener"))
+	return cmdListener
+}
+
+func pool() *cobra.Command {
+	var cmdPool = &cobra.Command{
+		Use:     "pool <subcommand>",
+		Aliases: []string{"pools"},
+		Short:   "Load balancer pools management",
+		Args:    cobra.MinimumNArgs(1),
+	}
+
+	var cmdList = &cobra.Command{
+		Use:     "list <loadbalancer>",
+		Aliases: []string{"ls"},
+		Short:   "Show pools of the load balancer",
+		Args:    cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			_, lbId, err := resourcePath(cmd, "v1/loadbalancers", "load balancer", args[0])
+			if err != nil {
+				return err
+			}
+			pools, body, err := lbPools(cmd, lbId)
+			if err != nil {
+				return err
+			}
+
+			if output.Format(cmd) == output.FmtJSON {
+				fmt.Println(string(body))
+				return nil
+			}
+
+			if len(pools) == 0 {
+				fmt.Printf("load balancer has no pools\n")
+				return nil
+			}
+
+			table := make([][]string, len(pools)+1)
+			table[0] = []string{"ID", "Name", "Protocol", "Algorithm", "Members"}
+			for i, p := range pools {
+				table[i+1] = []string{p.Id, p.Name, p.Protocol, p.LbAlgorithm, strconv.Itoa(len(p.Members))}
+			}
+			output.Table(table, output.Format(cmd))
+			return nil
+		},
+	}
+
+	var cmdCreate = &cobra.Command{
+		Use:   "create <loadbalancer>",
+		Short: "Add the pool to the load balancer",
+		Long: `Add the pool to the load balancer. Pool receives traffic from the listener,
+specified with "--listener" flag.`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			flags := cmd.Flags()
+			name, err := flags.GetString("name")
+			if err != nil {
+				return err
+			}
+			protocol, err := flags.GetString("protocol")
+			if err != nil {
+				return err
+			}
+			algorithm, err := flags.GetString("algorithm")
+			if err != nil {
+				return err
+			}
+			listener, err := flags.GetString("listener")
+			if err != nil {
+				return err
+			}
+			_, lbId, err := resourcePath(cmd, "v1/loadbalancers", "load balancer", args[0])
+			if err != nil {
+				return err
+			}
+			path, err := regionPath(cmd, "v1/lbpools")
+			if err != nil {
+				return err
+			}
+
+			req := map[string]any{
+				"loadbalancer_id": lbId,
+				"name":            name,
+				"protocol":        strings.ToUpper(protocol),
+				"lb_algorithm":    strings.ToUpper(algorithm),
+			}
+			if listener != "" {
+				_, listenerId, err := resourcePath(cmd, "v1/lblisteners", "listener", listener)
+				if err != nil {
+					return err
+				}
+				req["listener_id"] = listenerId
+			}
+
+			var tasks taskList
+			body, err := client.Post(path, req, &tasks)
+			if err != nil {
+				return fmt.Errorf("creating the pool: %w", err)
+			}
+			return handleTasks(cmd, body, tasks, func(finished []task) {
+				for _, t := range finished {
+					for _, id := range t.createdIds("pools") {
+						fmt.Printf("Pool %s created\n", id)
+					}
+				}
+			})
+		},
+	}
+	cmdCreate.Flags().String("name", "", "Pool name")
+	cmdCreate.Flags().String("protocol", "HTTP", "Protocol of the members ('HTTP', 'HTTPS', 'TCP', 'UDP' etc.)")
+	cmdCreate.Flags().String("algorithm", "ROUND_ROBIN", "Balancing algorithm ('ROUND_ROBIN', 'LEAST_CONNECTIONS' or 'SOURCE_IP')")
+	cmdCreate.Flags().String("listener", "", "Listener to receive traffic from")
+	cmdCreate.MarkFlagRequired("name")
+	cmdCreate.RegisterFlagCompletionFunc("algorithm", cobra.FixedCompletions(
+		[]string{"ROUND_ROBIN", "LEAST_CONNECTIONS", "SOURCE_IP"},
+		cobra.ShellCompDirectiveNoFileComp,
+	))
+
+	cmdPool.AddCommand(cmdList, cmdCreate, deleteCommand("v1/lbpools", "pool"))
+	return cmdPool
+}
+
+func poolMember() *cobra.Command {
+	var cmdMember = &cobra.Command{
+		Use:     "member <subcommand>",
+		Aliases: []string{"members"},
+		Short:   "Load balancer pool members management",
+		Args:    cobra.MinimumNArgs(1),
+	}
+
+	var cmdAdd = &cobra.Command{
+		Use:   "add <pool>",
+		Short: "Add the member to the pool",
+		Long: `Add the member to the pool. Member is specified by the address or by the
+instance with "--instance" flag.`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			flags := cmd.Flags()
+			address, err := flags.GetString("address")
+			if err != nil {
+				return err
+			}
+			port, err := flags.GetInt("port")
+			if err != nil {
+				return err
+			}
+			weight, err := flags.GetInt("weight")
+			if err != nil {
+				return err
+			}
+			instance, err := flags.GetString("instance")
+			if err != nil {
+				return err
+			}
+			subnet, err := flags.GetString("subnet")
+			if err != nil {
+				return err
+			}
+			path, poolId, err := resourcePath(cmd, "v1/lbpools", "pool", args[0])
+			if err != nil {
+				return err
+			}
+
+			req := map[string]any{"protocol_port": port, "weight": weight}
+			if instance != "" {
+				_, instId, err := instancePath(cmd, instance)
+				if err != nil {
+					return err
+				}
+				req["instance_id"] = instId
+			}
+			if address != "" {
+				req["address"] = address
+			}
+			if subnet != "" {
+				_, subnetId, err := resourcePath(cmd, "v1/subnets", "subnet", subnet)
+				if err != nil {
+					return err
+				}
+				req["subnet_id"] = subnetId
+			}
+
+			var tasks taskList
+			body, err := client.Post(path+"/member", req, &tasks)
+			if err != nil {
+				return fmt.Errorf("adding the member: %w", err)
+			}
+			return handleTasks(cmd, body, tasks, func([]task) {
+				fmt.Printf("Member added to pool %s\n", poolId)
+			})
+		},
+	}
+	cmdAdd.Flags().String("address", "", "Member IP address")
+	cmdAdd.Flags().Int("port", 80, "Member port")
+	cmdAdd.Flags().Int("weight", 1, "Member weight")
+	cmdAdd.Flags().String("instance", "", "Instance to add")
+	cmdAdd.Flags().String("subnet", "", "Subnet of the member address")
+	cmdAdd.MarkFlagsOneRequired("address", "instance")
+
+	var cmdRemove = &cobra.Command{
+		Use:     "remove <pool> <member_id>",
+		Aliases: []string{"rm"},
+		Short:   "Remove the member from the pool",
+		Args:    cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			path, poolId, err := resourcePath(cmd, "v1/lbpools", "pool", args[0])
+			if err != nil {
+				return err
+			}
+			if !sure.AreYou(cmd, fmt.Sprintf("remove member %s from pool %s", args[1], poolId)) {
+				return e.ErrAborted
+			}
+
+			var tasks taskList
+			body, err := client.Delete(path+"/member/"+url.PathEscape(args[1]), nil, &tasks)
+			if err != nil {
+				return fmt.Errorf("removing the member: %w", err)
+			}
+			return handleTasks(cmd, body, tasks, func([]task) {
+				fmt.Printf("Member %s removed from pool %s\n", args[1], poolId)
+			})
+		},
+	}
+
+	cmdMember.AddCommand(cmdAdd, cmdRemove)
+	return cmdMember
+}
+
+func healthMonitor() *cobra.Command {
+	var cmdMonitor = &cobra.Command{
+		Use:     "healthmonitor <subcommand>",
+		Aliases: []string{"hm"},
+		Short:   "Load balancer pool health monitor settings",
+		Args:    cobra.MinimumNArgs(1),
+	}
+
+	var cmdSet = &cobra.Command{
+		Use:   "set <pool>",
+		Short: "Set the pool health monitor",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			hm, err := parseHealthMonitor(cmd)
+			if err != nil {
+				return err
+			}
+			path, poolId, err := resourcePath(cmd, "v1/lbpools", "pool", args[0])
+			if err != nil {
+				return err
+			}
+
+			var tasks taskList
+			body, err := client.Post(path+"/healthmonitor", hm, &tasks)
+			if err != nil {
+				return fmt.Errorf("setting the health monitor: %w", err)
+			}
+			return handleTasks(cmd, body, tasks, func([]task) {
+				fmt.Printf("Health monitor of pool %s set to %s\n", poolId, hm)
+			})
+		},
+	}
+	cmdSet.Flags().String("type", "HTTP", "Check type ('HTTP', 'HTTPS', 'PING', 'TCP' etc.)")
+	cmdSet.Flags().Int("delay", 10, "Interval between checks, seconds")
+	cmdSet.Flags().Int("timeout", 5, "Check timeout, seconds")
+	cmdSet.Flags().Int("retries", 3, "Number of failed checks to consider the member down")
+	cmdSet.Flags().String("http-method", "GET", "HTTP method of the check")
+	cmdSet.Flags().String("url-path", "/", "URL path of the check")
+	cmdSet.Flags().String("expected-codes", "200", "Expected HTTP status codes, like '200' or '200-204'")
+
+	var cmdDelete = &cobra.Command{
+		Use:     "delete <pool>",
+		Aliases: []string{"rm"},
+		Short:   "Delete the pool health monitor",
+		Args:    cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			path, poolId, err := resourcePath(cmd, "v1/lbpools", "pool", args[0])
+			if err != nil {
+				return err
+			}
+			if !sure.AreYou(cmd, fmt.Sprintf("delete health monitor of pool %s", poolId)) {
+				return e.ErrAborted
+			}
+
+			if _, err := client.Delete(path+"/healthmonitor", nil, nil); err != nil {
+				return fmt.Errorf("deleting the health monitor: %w", err)
+			}
+			fmt.Printf("Health monitor of pool %s deleted\n", poolId)
+			return nil
+		},
+	}
+
+	cmdMonitor.AddCommand(cmdSet, cmdDelete)
+	return cmdMonitor
+}
+
+func parseHealthMonitor(cmd *cobra.Command) (*healthMonitorInfo, error) {
+	flags := cmd.Flags()
+	var hm healthMonitorInfo
+	var err error
+	if hm.Type, err = flags.GetString("type"); err != nil {
+		return nil, err
+	}
+	hm.Type = strings.ToUpper(hm.Type)
+	if hm.Delay, err = flags.GetInt("delay"); err != nil {
+		return nil, err
+	}
+	if hm.Timeout, err = flags.GetInt("timeout"); err != nil {
+		return nil, err
+	}
+	if hm.MaxRetries, err = flags.GetInt("retries"); err != nil {
+		return nil, err
+	}
+	if hm.Type == "HTTP" || hm.Type == "HTTPS" {
+		for name, val := range map[string]**string{
+			"http-method":    &hm.HttpMethod,
+			"url-path":       &hm.UrlPath,
+			"expected-codes": &hm.ExpectedCodes,
+		} {
+			s, err := flags.GetString(name)
+			if err != nil {
+				return nil, err
+			}
+			*val = &s
+		}
+	}
+	return &hm, nil
+}
@@ -0,0 +1,43 @@
+package cloud
+
+import (
+	"testing"
+
+	"github.com/alecthomas/assert"
+)
+
+func TestNewLoadBalancerView(t *testing.T) {
+	method, path, codes := "GET", "/health", "200"
+	lb := &loadBalancerInfo{Id: "lb1", Name: "web", ProvisioningStatus: "ACTIVE", OperatingStatus: "ONLINE"}
+	listeners := []listenerInfo{{Id: "l1", Name: "http", Protocol: "HTTP", ProtocolPort: 80}}
+	pools := []poolInfo{
+		{
+			Id:          "p1",
+			Name:        "backend",
+			Protocol:    "HTTP",
+			LbAlgorithm: "ROUND_ROBIN",
+			Members: []poolMemberInfo{
+				{Id: "m1", Address: "10.0.0.1", ProtocolPort: 8080, Weight: 1},
+				{Id: "m2", Address: "10.0.0.2", ProtocolPort: 8080, Weight: 2},
+			},
+			HealthMonitor: &healthMonitorInfo{
+				Type: "HTTP", Delay: 10, Timeout: 5, MaxRetries: 3,
+				HttpMethod: &method, UrlPath: &path, ExpectedCodes: &codes,
+			},
+		},
+		{Id: "p2", Name: "empty", Protocol: "TCP", LbAlgorithm: "SOURCE_IP"},
+	}
+
+	view := newLoadBalancerView(lb, listeners, pools)
+	assert.Equal(t, 1, len(view.Listeners))
+	assert.Equal(t, 2, len(view.Pools))
+	assert.Equal(t, 2, view.Pools[0].Members)
+	assert.Equal(t, "HTTP GET /health every 10s, timeout 5s, 3 retries, expecting 200", view.Pools[0].HealthMonitor)
+	assert.Equal(t, "", view.Pools[1].HealthMonitor)
+	assert.Equal(t, 2, len(view.Members))
+	assert.Equal(t, "backend", view.Members[1].Pool)
+	assert.Equal(t, "10.0.0.2", view.Members[1].Address)
+
+	view = newLoadBalancerView(lb, nil, nil)
+	assert.True(t, view.Listeners == nil && view.Pools == nil && view.Members == nil)
+}
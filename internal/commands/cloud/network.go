@@ -0,0 +1,413 @@
+package cloud
+
+import (
+	"fmt"
+	"net"
+	"strconv"
+
+	"github.com/spf13/cobra"
+
+	e "github.com/G-core/gcore-cli/internal/errors"
+	"github.com/G-core/gcore-cli/internal/output"
+	"github.com/G-core/gcore-cli/internal/sure"
+)
+
+type networkInfo struct {
+	Id        string   `json:"id"`
+	Name      string   `json:"name"`
+	Type      string   `json:"type"`
+	External  bool     `json:"external"`
+	Mtu       int      `json:"mtu"`
+	Subnets   []string `json:"subnets"`
+	CreatedAt string   `json:"created_at"`
+}
+
+type subnetInfo struct {
+	Id         string `json:"id"`
+	Name       string `json:"name"`
+	Cidr       string `json:"cidr"`
+	NetworkId  string `json:"network_id"`
+	GatewayIp  string `json:"gateway_ip"`
+	EnableDhcp bool   `json:"enable_dhcp"`
+}
+
+type floatingIpInfo struct {
+	Id                string  `json:"id"`
+	FloatingIpAddress string  `json:"floating_ip_address"`
+	FixedIpAddress    *string `json:"fixed_ip_address"`
+	PortId            *string `json:"port_id"`
+	Status            string  `json:"status"`
+	CreatedAt         string  `json:"created_at"`
+}
+
+func network() *cobra.Command {
+	var cmdNetwork = &cobra.Command{
+		Use:     "network <subcommand>",
+		Aliases: []string{"networks"},
+		Short:   "Private networks management",
+		Long:    `Private networks management. Networks can be specified by ID or name.`,
+		Args:    cobra.MinimumNArgs(1),
+	}
+
+	var cmdList = &cobra.Command{
+		Use:     "list",
+		Aliases: []string{"ls"},
+		Short:   "Show list of networks",
+		Args:    cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			path, err := regionPath(cmd, "v1/networks")
+			if err != nil {
+				return err
+			}
+
+			var rsp list[networkInfo]
+			body, err := client.Get(path, nil, &rsp)
+			if err != nil {
+				return fmt.Errorf("getting the list of networks: %w", err)
+			}
+
+			if output.Format(cmd) == output.FmtJSON {
+				fmt.Println(string(body))
+				return nil
+			}
+
+			if len(rsp.Results) == 0 {
+				fmt.Printf("you have no networks\n")
+				return nil
+			}
+
+			table := make([][]string, len(rsp.Results)+1)
+			table[0] = []string{"ID", "Name", "Type", "External", "Subnets", "Created"}
+			for i, n := range rsp.Results {
+				table[i+1] = []string{
+					n.Id,
+					n.Name,
+					n.Type,
+					strconv.FormatBool(n.External),
+					strconv.Itoa(len(n.Subnets)),
+					n.CreatedAt,
+				}
+			}
+			output.Table(table, output.Format(cmd))
+			return nil
+		},
+	}
+
+	var cmdCreate = &cobra.Command{
+		Use:   "create",
+		Short: "Create new network",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			path, err := regionPath(cmd, "v1/networks")
+			if err != nil {
+				return err
+			}
+			name, err := cmd.Flags().GetString("name")
+			if err != nil {
+				return err
+			}
+			netType, err := cmd.Flags().GetString("type")
+			if err != nil {
+				return err
+			}
+			noRouter, err := cmd.Flags().GetBool("no-router")
+			if err != nil {
+				return err
+			}
+
+			req := map[string]any{"name": name, "type": netType, "create_router": !noRouter}
+			var tasks taskList
+			body, err := client.Post(path, req, &tasks)
+			if err != nil {
+				return fmt.Errorf("creating the network: %w", err)
+			}
+			return handleTasks(cmd, body, tasks, func(finished []task) {
+				for _, t := range finished {
+					for _, id := range t.createdIds("networks") {
+						fmt.Printf("Network %s created\n", id)
+					}
+				}
+			})
+		},
+	}
+	cmdCreate.Flags().String("name", "", "Network name")
+	cmdCreate.Flags().String("type", "vxlan", "Network type, 'vxlan' or 'vlan'")
+	cmdCreate.Flags().Bool("no-router", false, "Don't create the router for the network")
+	cmdCreate.MarkFlagRequired("name")
+
+	cmdNetwork.AddCommand(cmdList, cmdCreate, deleteCommand("v1/networks", "network"))
+	return cmdNetwork
+}
+
+func subnet() *cobra.Command {
+	var cmdSubnet = &cobra.Command{
+		Use:     "subnet <subcommand>",
+		Aliases: []string{"subnets"},
+		Short:   "Network subnets management",
+		Long:    `Network subnets management. Subnets and networks can be specified by ID or name.`,
+		Args:    cobra.MinimumNArgs(1),
+	}
+
+	var cmdList = &cobra.Command{
+		Use:     "list",
+		Aliases: []string{"ls"},
+		Short:   "Show list of subnets",
+		Args:    cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			path, err := regionPath(cmd, "v1/subnets")
+			if err != nil {
+				return err
+			}
+			var rsp list[subnetInfo]
+			body, err := client.Get(path, nil, &rsp)
+			if err != nil {
+				return fmt.Errorf("getting the list of subnets: %w", err)
+			}
+
+			if output.Format(cmd) == output.FmtJSON {
+				fmt.Println(string(body))
+				return nil
+			}
+
+			if len(rsp.Results) == 0 {
+				fmt.Printf("you have no subnets\n")
+				return nil
+			}
+
+			table := make([][]string, len(rsp.Results)+1)
+			table[0] = []string{"ID", "Name", "CIDR", "Gateway", "DHCP", "Network"}
+			for i, s := range rsp.Results {
+				table[i+1] = []string{s.Id, s.Name, s.Cidr, s.GatewayIp, strconv.FormatBool(s.EnableDhcp), s.NetworkId}
+			}
+			output.Table(table, output.Format(cmd))
+			return nil
+		},
+	}
+
+	var cmdCreate = &cobra.Command{
+		Use:   "create",
+		Short: "Create new subnet in the network",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			flags := cmd.Flags()
+			name, err := flags.GetString("name")
+			if err != nil {
+				return err
+			}
+			network, err := flags.GetString("network")
+			if err != nil {
+				return err
+			}
+			cidr, err := flags.GetString("cidr")
+			if err != nil {
+				return err
+			}
+			gateway, err := flags.GetString("gateway")
+			if err != nil {
+				return err
+			}
+			dns, err := flags.GetStringSlice("dns")
+			if err != nil {
+				return err
+			}
+			noDhcp, err := flags.GetBool("no-dhcp")
+			if err != nil {
+				return err
+			}
+
+			_, ipNet, err := net.ParseCIDR(cidr)
+			if err != nil {
+				return fmt.Errorf("invalid CIDR: %w", err)
+			}
+			_, networkId, err := resourcePath(cmd, "v1/networks", "network", network)
+			if err != nil {
+				return err
+			}
+			path, err := regionPath(cmd, "v1/subnets")
+			if err != nil {
+				return err
+			}
+
+			req := map[string]any{
+				"name":                      name,
+				"network_id":                networkId,
+				"cidr":                      ipNet.String(),
+				"enable_dhcp":               !noDhcp,
+				"connect_to_network_router": true,
+			}
+			if gateway != "" {
+				req["gateway_ip"] = gateway
+			}
+			if len(dns) > 0 {
+				req["dns_nameservers"] = dns
+			}
+
+			var tasks taskList
+			body, err := client.Post(path, req, &tasks)
+			if err != nil {
+				return fmt.Errorf("creating the subnet: %w", err)
+			}
+			return handleTasks(cmd, body, tasks, func(finished []task) {
+				for _, t := range finished {
+					for _, id := range t.createdIds("subnets") {
+						fmt.Printf("Subnet %s created\n", id)
+					}
+				}
+			})
+		},
+	}
+	cmdCreate.Flags().String("name", "", "Subnet name")
+	cmdCreate.Flags().String("network", "", "Network of the subnet")
+	cmdCreate.Flags().String("cidr", "", "Subnet CIDR, like '192.168.10.0/24'")
+	cmdCreate.Flags().String("gateway", "", "Gateway IP (default is the first address of the subnet)")
+	cmdCreate.Flags().StringSlice("dns", nil, "DNS nameservers")
+	cmdCreate.Flags().Bool("no-dhcp", false, "Disable DHCP in the subnet")
+	cmdCreate.MarkFlagRequired("name")
+	cmdCreate.MarkFlagRequired("network")
+	cmdCreate.MarkFlagRequired("cidr")
+
+	cmdSubnet.AddCommand(cmdList, cmdCreate, deleteCommand("v1/subnets", "subnet"))
+	return cmdSubnet
+}
+
+func floatingIp() *cobra.Command {
+	var cmdFloating = &cobra.Command{
+		Use:     "floatingip <subcommand>",
+		Aliases: []string{"floatingips", "fip"},
+		Short:   "Floating IPs management",
+		Long:    `Floating IPs management. Floating IPs can be specified by ID or address.`,
+		Args:    cobra.MinimumNArgs(1),
+	}
+
+	var cmdList = &cobra.Command{
+		Use:     "list",
+		Aliases: []string{"ls"},
+		Short:   "Show list of floating IPs",
+		Args:    cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			path, err := regionPath(cmd, "v1/floatingips")
+			if err != nil {
+				return err
+			}
+			var rsp list[floatingIpInfo]
+			body, err := client.Get(path, nil, &rsp)
+			if err != nil {
+				return fmt.Errorf("getting the list of floating IPs: %w", err)
+			}
+
+			if output.Format(cmd) == output.FmtJSON {
+				fmt.Println(string(body))
+				return nil
+			}
+
+			if len(rsp.Results) == 0 {
+				fmt.Printf("you have no floating IPs\n")
+				return nil
+			}
+
+			table := make([][]string, len(rsp.Results)+1)
+			table[0] = []string{"ID", "Address", "Fixed address", "Status", "Created"}
+			for i, f := range rsp.Results {
+				fixed := ""
+				if f.FixedIpAddress != nil {
+					fixed = *f.FixedIpAddress
+				}
+				table[i+1] = []string{f.Id, f.FloatingIpAddress, fixed, f.Status, f.CreatedAt}
+			}
+			output.Table(table, output.Format(cmd))
+			return nil
+		},
+	}
+
+	var cmdCreate = &cobra.Command{
+		Use:   "create",
+		Short: "Allocate new floating IP",
+		Long: `Allocate new floating IP. It is assigned to the instance port, if "--port"
+flag is specified.`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			path, err := regionPath(cmd, "v1/floatingips")
+			if err != nil {
+				return err
+			}
+			port, err := cmd.Flags().GetString("port")
+			if err != nil {
+				return err
+			}
+			fixed, err := cmd.Flags().GetString("fixed-ip")
+			if err != nil {
+				return err
+			}
+
+			req := map[string]string{}
+			if port != "" {
+				req["port_id"] = port
+			}
+			if fixed != "" {
+				req["fixed_ip_address"] = fixed
+			}
+			var tasks taskList
+			body, err := client.Post(path, req, &tasks)
+			if err != nil {
+				return fmt.Errorf("creating the floating IP: %w", err)
+			}
+			return handleTasks(cmd, body, tasks, func(finished []task) {
+				for _, t := range finished {
+					for _, id := range t.createdIds("floatingips") {
+						fmt.Printf("Floating IP %s created\n", id)
+					}
+				}
+			})
+		},
+	}
+	cmdCreate.Flags().String("port", "", "Instance port ID to assign the IP to")
+	cmdCreate.Flags().String("fixed-ip", "", "Fixed IP address of the port")
+
+	var cmdDelete = &cobra.Command{
+		Use:     "delete <floating_ip>",
+		Aliases: []string{"rm"},
+		Short:   "Release the floating IP",
+		Args:    cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			path, err := regionPath(cmd, "v1/floatingips")
+			if err != nil {
+				return err
+			}
+			id := args[0]
+			if net.ParseIP(id) != nil {
+				if id, err = floatingIpId(path, id); err != nil {
+					return err
+				}
+			}
+			if !sure.AreYou(cmd, fmt.Sprintf("release floating IP %s", args[0])) {
+				return e.ErrAborted
+			}
+
+			var tasks taskList
+			body, err := client.Delete(path+"/"+id, nil, &tasks)
+			if err != nil {
+				return fmt.Errorf("deleting the floating IP: %w", err)
+			}
+			return handleTasks(cmd, body, tasks, func([]task) {
+				fmt.Printf("Floating IP %s released\n", args[0])
+			})
+		},
+	}
+
+	cmdFloating.AddCommand(cmdList, cmdCreate, cmdDelete)
+	return cmdFloating
+}
+
+// floatingIpId returns ID of the floating IP with the address
+func floatingIpId(path, addr string) (string, error) {
+	var rsp list[floatingIpInfo]
+	if _, err := client.Get(path, nil, &rsp); err != nil {
+		return "", fmt.Errorf("getting the list of floating IPs: %w", err)
+	}
+	for _, f := range rsp.Results {
+		if f.FloatingIpAddress == addr {
+			return f.Id, nil
+		}
+	}
+	return "", fmt.Errorf("floating IP %s not found", addr)
+}
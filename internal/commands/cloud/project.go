@@ -0,0 +1,345 @@
+package cloud
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/G-core/gcore-cli/internal/apiclient"
+	"github.com/G-core/gcore-cli/internal/config"
+	e "github.com/G-core/gcore-cli/internal/errors"
+	"github.com/G-core/gcore-cli/internal/output"
+	"github.com/G-core/gcore-cli/internal/sure"
+)
+
+type projectInfo struct {
+	Id          int    `json:"id"`
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	State       string `json:"state"`
+	IsDefault   bool   `json:"is_default"`
+	CreatedAt   string `json:"created_at"`
+}
+
+type regionInfo struct {
+	Id           int    `json:"id"`
+	DisplayName  string `json:"display_name"`
+	KeystoneName string `json:"keystone_name"`
+	State        string `json:"state"`
+	Country      string `json:"country"`
+	Zone         string `json:"zone"`
+}
+
+// resolvedIds keeps project and region IDs, resolved from names, so names
+// are looked up only once per invocation
+var resolvedIds = map[string]int{}
+
+func project() *cobra.Command {
+	var cmdProject = &cobra.Command{
+		Use:     "project <subcommand>",
+		Aliases: []string{"projects"},
+		Short:   "Cloud projects management",
+		Long: `Cloud projects management. Projects can be specified by ID or name,
+both in command arguments and in "--project" flag.`,
+		Args: cobra.MinimumNArgs(1),
+	}
+
+	var cmdList = &cobra.Command{
+		Use:     "list",
+		Aliases: []string{"ls"},
+		Short:   "Show list of projects",
+		Args:    cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			var rsp list[projectInfo]
+			body, err := client.Get("/v1/projects", nil, &rsp)
+			if err != nil {
+				return fmt.Errorf("getting the list of projects: %w", err)
+			}
+
+			if output.Format(cmd) == output.FmtJSON {
+				fmt.Println(string(body))
+				return nil
+			}
+
+			if len(rsp.Results) == 0 {
+				fmt.Printf("you have no projects\n")
+				return nil
+			}
+
+			table := make([][]string, len(rsp.Results)+1)
+			table[0] = []string{"ID", "Name", "State", "Default", "Created"}
+			for i, p := range rsp.Results {
+				def := ""
+				if p.IsDefault {
+					def = "yes"
+				}
+				table[i+1] = []string{strconv.Itoa(p.Id), p.Name, p.State, def, p.CreatedAt}
+			}
+			output.Table(table, output.Format(cmd))
+			return nil
+		},
+	}
+
+	var cmdShow = &cobra.Command{
+		Use:     "show <project>",
+		Aliases: []string{"get"},
+		Short:   "Show project details",
+		Args:    cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			id, err := projectId(args[0])
+			if err != nil {
+				return err
+			}
+
+			var p projectInfo
+			body, err := client.Get("/v1/projects/"+strconv.Itoa(id), nil, &p)
+			if err != nil {
+				return fmt.Errorf("getting project details: %w", err)
+			}
+
+			if output.Format(cmd) == output.FmtJSON {
+				fmt.Println(string(body))
+				return nil
+			}
+
+			fmt.Printf("ID:\t     %d\nName:\t     %s\nState:\t     %s\nDefault:     %t\nCreated:     %s\n", p.Id, p.Name, p.State, p.IsDefault, p.CreatedAt)
+			if p.Description != "" {
+				fmt.Printf("Description: %s\n", p.Description)
+			}
+			return nil
+		},
+	}
+
+	var cmdCreate = &cobra.Command{
+		Use:   "create",
+		Short: "Create new project",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			name, err := cmd.Flags().GetString("name")
+			if err != nil {
+				return err
+			}
+			description, err := cmd.Flags().GetString("description")
+			if err != nil {
+				return err
+			}
+
+			var p projectInfo
+			body, err := client.Post("/v1/projects", map[string]string{"name": name, "description": description}, &p)
+			if err != nil {
+				return fmt.Errorf("creating the project: %w", err)
+			}
+
+			if output.Format(cmd) == output.FmtJSON {
+				fmt.Println(string(body))
+				return nil
+			}
+			fmt.Printf("Project %d created\n", p.Id)
+			return nil
+		},
+	}
+	cmdCreate.Flags().String("name", "", "Project name")
+	cmdCreate.Flags().String("description", "", "Project description")
+	cmdCreate.MarkFlagRequired("name")
+
+	var cmdDelete = &cobra.Command{
+		Use:     "delete <project>",
+		Aliases: []string{"rm"},
+		Short:   "Delete the project with all its resources",
+		Args:    cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			id, err := projectId(args[0])
+			if err != nil {
+				return err
+			}
+			if !sure.AreYou(cmd, fmt.Sprintf("delete project %d with all its resources", id)) {
+				return e.ErrAborted
+			}
+
+			var tasks taskList
+			body, err := client.Delete("/v1/projects/"+strconv.Itoa(id), nil, &tasks)
+			if err != nil {
+				return fmt.Errorf("deleting the project: %w", err)
+			}
+			return handleTasks(cmd, body, tasks, func([]task) {
+				fmt.Printf("Project %d deleted\n", id)
+			})
+		},
+	}
+
+	var cmdUse = &cobra.Command{
+		Use:   "use <project>",
+		Short: "Set the default project",
+		Long: `Set the default project, used when "--project" flag is not specified.
+The default is stored in CLI config file.`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			id, err := projectId(args[0])
+			if err != nil {
+				return err
+			}
+			if err := config.Set("project", id); err != nil {
+				return fmt.Errorf("saving the default project: %w", err)
+			}
+			fmt.Printf("Default project is %d\n", id)
+			return nil
+		},
+	}
+
+	cmdProject.AddCommand(cmdList, cmdShow, cmdCreate, cmdDelete, cmdUse)
+	return cmdProject
+}
+
+func region() *cobra.Command {
+	var cmdRegion = &cobra.Command{
+		Use:     "region <subcommand>",
+		Aliases: []string{"regions"},
+		Short:   "Cloud regions",
+		Long: `Cloud regions. Regions can be specified by ID, name or code (like
+"ED-10"), both in command arguments and in "--region" flag.`,
+		Args: cobra.MinimumNArgs(1),
+	}
+
+	var cmdList = &cobra.Command{
+		Use:     "list",
+		Aliases: []string{"ls"},
+		Short:   "Show list of regions",
+		Args:    cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			var rsp list[regionInfo]
+			body, err := client.Get("/v1/regions", nil, &rsp)
+			if err != nil {
+				return fmt.Errorf("getting the list of regions: %w", err)
+			}
+
+			if output.Format(cmd) == output.FmtJSON {
+				fmt.Println(string(body))
+				return nil
+			}
+
+			table := make([][]string, len(rsp.Results)+1)
+			table[0] = []string{"ID", "Name", "Code", "Country", "Zone", "State"}
+			for i, r := range rsp.Results {
+				table[i+1] = []string{strconv.Itoa(r.Id), r.DisplayName, r.KeystoneName, r.Country, r.Zone, r.State}
+			}
+			output.Table(table, output.Format(cmd))
+			return nil
+		},
+	}
+
+	var cmdUse = &cobra.Command{
+		Use:   "use <region>",
+		Short: "Set the default region",
+		Long: `Set the default region, used when "--region" flag is not specified.
+The default is stored in CLI config file.`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			id, err := regionId(args[0])
+			if err != nil {
+				return err
+			}
+			if err := config.Set("region", id); err != nil {
+				return fmt.Errorf("saving the default region: %w", err)
+			}
+			fmt.Printf("Default region is %d\n", id)
+			return nil
+		},
+	}
+
+	cmdRegion.AddCommand(cmdList, cmdUse)
+	return cmdRegion
+}
+
+// resolveProject returns ID of the project, specified by "--project" flag,
+// or 0 if the flag is not set
+func resolveProject(cmd *cobra.Command) (int, error) {
+	return resolveFlag(cmd, "project", projectId)
+}
+
+// resolveRegion returns ID of the region, specified by "--region" flag, or
+// 0 if the flag is not set
+func resolveRegion(cmd *cobra.Command) (int, error) {
+	return resolveFlag(cmd, "region", regionId)
+}
+
+func resolveFlag(cmd *cobra.Command, name string, resolve func(string) (int, error)) (int, error) {
+	if id, ok := resolvedIds[name]; ok {
+		return id, nil
+	}
+	val, err := cmd.Flags().GetString(name)
+	if err != nil {
+		return 0, err
+	}
+	id := 0
+	if val != "" {
+		if id, err = resolve(val); err != nil {
+			return 0, err
+		}
+	}
+	resolvedIds[name] = id
+	return id, nil
+}
+
+// projectId returns ID of the project, specified by ID or name
+func projectId(arg string) (int, error) {
+	if id, err := strconv.Atoi(arg); err == nil {
+		return id, nil
+	}
+	var rsp list[projectInfo]
+	if _, err := client.Get("/v1/projects", nil, &rsp); err != nil {
+		return 0, fmt.Errorf("getting the list of projects: %w", err)
+	}
+	// exact name wins over case-insensitive matches
+	var exact, similar []projectInfo
+	names := make([]string, len(rsp.Results))
+	for i, p := range rsp.Results {
+		if p.Name == arg {
+			exact = append(exact, p)
+		} else if strings.EqualFold(p.Name, arg) {
+			similar = append(similar, p)
+		}
+		names[i] = p.Name
+	}
+	matches := exact
+	if len(matches) == 0 {
+		matches = similar
+	}
+
+	switch len(matches) {
+	case 1:
+		return matches[0].Id, nil
+	case 0:
+		return 0, apiclient.NotFound("project", arg, names)
+	}
+	lines := make([]string, len(matches))
+	for i, p := range matches {
+		lines[i] = fmt.Sprintf("%d\t%s", p.Id, p.Name)
+	}
+	return 0, &e.CliError{
+		Err:     fmt.Errorf("project name '%s' is ambiguous, it matches %d projects", arg, len(matches)),
+		Details: "matching projects:\n" + strings.Join(lines, "\n"),
+		Hint:    "Specify project by ID",
+		Code:    1,
+	}
+}
+
+// regionId returns ID of the region, specified by ID, name or code
+func regionId(arg string) (int, error) {
+	if id, err := strconv.Atoi(arg); err == nil {
+		return id, nil
+	}
+	var rsp list[regionInfo]
+	if _, err := client.Get("/v1/regions", nil, &rsp); err != nil {
+		return 0, fmt.Errorf("getting the list of regions: %w", err)
+	}
+	var names []string
+	for _, r := range rsp.Results {
+		if strings.EqualFold(r.DisplayName, arg) || strings.EqualFold(r.KeystoneName, arg) {
+			return r.Id, nil
+		}
+		names = append(names, r.DisplayName, r.KeystoneName)
+	}
+	return 0, apiclient.NotFound("region", arg, names)
+}
@@ -0,0 +1,65 @@
+package cloud
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/alecthomas/assert"
+	"github.com/spf13/cobra"
+
+	e "github.com/G-core/gcore-cli/internal/errors"
+)
+
+func TestResolveProjectRegion(t *testing.T) {
+	calls := 0
+	testClient(t, func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		switch r.URL.Path {
+		case "/v1/projects":
+			io.WriteString(w, `{"count":2,"results":[{"id":1,"name":"prod"},{"id":5,"name":"dev"}]}`)
+		case "/v1/regions":
+			io.WriteString(w, `{"count":1,"results":[{"id":76,"display_name":"Luxembourg-2","keystone_name":"ED-10"}]}`)
+		}
+	})
+	t.Cleanup(func() { resolvedIds = map[string]int{} })
+
+	cmd := &cobra.Command{}
+	cmd.Flags().String("project", "dev", "")
+	cmd.Flags().String("region", "ed-10", "")
+
+	project, region, err := projectRegion(cmd)
+	assert.NoError(t, err)
+	assert.Equal(t, 5, project)
+	assert.Equal(t, 76, region)
+
+	// names are resolved only once
+	_, _, err = projectRegion(cmd)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, calls)
+
+	id, err := projectId("42")
+	assert.NoError(t, err)
+	assert.Equal(t, 42, id)
+	assert.Equal(t, 2, calls)
+
+	_, err = regionId("Luxembourg")
+	assert.EqualError(t, err, "region 'Luxembourg' not found")
+}
+
+func TestProjectIdAmbiguous(t *testing.T) {
+	testClient(t, func(w http.ResponseWriter, r *http.Request) {
+		io.WriteString(w, `{"count":3,"results":[{"id":1,"name":"Prod"},{"id":2,"name":"prod"},{"id":3,"name":"PROD"}]}`)
+	})
+
+	id, err := projectId("prod")
+	assert.NoError(t, err)
+	assert.Equal(t, 2, id)
+
+	_, err = projectId("pROD")
+	var cliErr *e.CliError
+	assert.True(t, errors.As(err, &cliErr))
+	assert.Equal(t, "project name 'pROD' is ambiguous, it matches 3 projects", cliErr.Error())
+	assert.Equal(t, "matching projects:\n1\tProd\n2\tprod\n3\tPROD", cliErr.Details)
+}
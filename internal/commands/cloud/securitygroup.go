@@ -0,0 +1,323 @@
+package cloud
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	e "github.com/G-core/gcore-cli/internal/errors"
+	"github.com/G-core/gcore-cli/internal/output"
+	"github.com/G-core/gcore-cli/internal/sure"
+)
+
+type securityGroupRule struct {
+	Id             string  `json:"id,omitempty"`
+	Direction      string  `json:"direction"`
+	Ethertype      string  `json:"ethertype,omitempty"`
+	Protocol       *string `json:"protocol,omitempty"`
+	PortRangeMin   *int    `json:"port_range_min,omitempty"`
+	PortRangeMax   *int    `json:"port_range_max,omitempty"`
+	RemoteIpPrefix *string `json:"remote_ip_prefix,omitempty"`
+	Description    string  `json:"description,omitempty"`
+}
+
+type securityGroupInfo struct {
+	Id          string              `json:"id"`
+	Name        string              `json:"name"`
+	Description string              `json:"description"`
+	Rules       []securityGroupRule `json:"security_group_rules"`
+	CreatedAt   string              `json:"created_at"`
+}
+
+func (r *securityGroupRule) ports() string {
+	switch {
+	case r.PortRangeMin == nil:
+		return "any"
+	case r.PortRangeMax == nil || *r.PortRangeMax == *r.PortRangeMin:
+		return strconv.Itoa(*r.PortRangeMin)
+	}
+	return fmt.Sprintf("%d-%d", *r.PortRangeMin, *r.PortRangeMax)
+}
+
+func securityGroup() *cobra.Command {
+	var cmdGroup = &cobra.Command{
+		Use:     "securitygroup <subcommand>",
+		Aliases: []string{"securitygroups", "sg"},
+		Short:   "Security groups management",
+		Long:    `Security groups management. Security groups can be specified by ID or name.`,
+		Args:    cobra.MinimumNArgs(1),
+	}
+
+	var cmdList = &cobra.Command{
+		Use:     "list",
+		Aliases: []string{"ls"},
+		Short:   "Show list of security groups",
+		Args:    cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			path, err := regionPath(cmd, "v1/securitygroups")
+			if err != nil {
+				return err
+			}
+			var rsp list[securityGroupInfo]
+			body, err := client.Get(path, nil, &rsp)
+			if err != nil {
+				return fmt.Errorf("getting the list of security groups: %w", err)
+			}
+
+			if output.Format(cmd) == output.FmtJSON {
+				fmt.Println(string(body))
+				return nil
+			}
+
+			if len(rsp.Results) == 0 {
+				fmt.Printf("you have no security groups\n")
+				return nil
+			}
+
+			table := make([][]string, len(rsp.Results)+1)
+			table[0] = []string{"ID", "Name", "Rules", "Description"}
+			for i, g := range rsp.Results {
+				table[i+1] = []string{g.Id, g.Name, strconv.Itoa(len(g.Rules)), g.Description}
+			}
+			output.Table(table, output.Format(cmd))
+			return nil
+		},
+	}
+
+	var cmdCreate = &cobra.Command{
+		Use:   "create",
+		Short: "Create new security group",
+		Long: `Create new security group. New group has default rules, allowing all
+egress traffic, use "securitygroup rule add" command to allow ingress traffic.`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			path, err := regionPath(cmd, "v1/securitygroups")
+			if err != nil {
+				return err
+			}
+			name, err := cmd.Flags().GetString("name")
+			if err != nil {
+				return err
+			}
+			description, err := cmd.Flags().GetString("description")
+			if err != nil {
+				return err
+			}
+
+			req := map[string]any{
+				"security_group": map[string]any{
+					"name":                 name,
+					"description":          description,
+					"security_group_rules": []securityGroupRule{},
+				},
+			}
+			var group securityGroupInfo
+			body, err := client.Post(path, req, &group)
+			if err != nil {
+				return fmt.Errorf("creating the security group: %w", err)
+			}
+
+			if output.Format(cmd) == output.FmtJSON {
+				fmt.Println(string(body))
+				return nil
+			}
+			fmt.Printf("Security group %s created\n", group.Id)
+			return nil
+		},
+	}
+	cmdCreate.Flags().String("name", "", "Security group name")
+	cmdCreate.Flags().String("description", "", "Security group description")
+	cmdCreate.MarkFlagRequired("name")
+
+	var cmdDelete = &cobra.Command{
+		Use:     "delete <security_group>",
+		Aliases: []string{"rm"},
+		Short:   "Delete the security group",
+		Args:    cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			path, id, err := resourcePath(cmd, "v1/securitygroups", "security group", args[0])
+			if err != nil {
+				return err
+			}
+			if !sure.AreYou(cmd, fmt.Sprintf("delete security group %s", id)) {
+				return e.ErrAborted
+			}
+
+			if _, err := client.Delete(path, nil, nil); err != nil {
+				return fmt.Errorf("deleting the security group: %w", err)
+			}
+			fmt.Printf("Security group %s deleted\n", id)
+			return nil
+		},
+	}
+
+	cmdGroup.AddCommand(cmdList, cmdCreate, cmdDelete, securityGroupRules())
+	return cmdGroup
+}
+
+func securityGroupRules() *cobra.Command {
+	var cmdRule = &cobra.Command{
+		Use:     "rule <subcommand>",
+		Aliases: []string{"rules"},
+		Short:   "Security group rules management",
+		Args:    cobra.MinimumNArgs(1),
+	}
+
+	var cmdList = &cobra.Command{
+		Use:     "list <security_group>",
+		Aliases: []string{"ls"},
+		Short:   "Show rules of the security group",
+		Args:    cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			path, _, err := resourcePath(cmd, "v1/securitygroups", "security group", args[0])
+			if err != nil {
+				return err
+			}
+			var group securityGroupInfo
+			body, err := client.Get(path, nil, &group)
+			if err != nil {
+				return fmt.Errorf("getting security group details: %w", err)
+			}
+
+			if output.Format(cmd) == output.FmtJSON {
+				fmt.Println(string(body))
+				return nil
+			}
+
+			if len(group.Rules) == 0 {
+				fmt.Printf("security group has no rules\n")
+				return nil
+			}
+
+			table := make([][]string, len(group.Rules)+1)
+			table[0] = []string{"ID", "Direction", "Ethertype", "Protocol", "Ports", "Remote", "Description"}
+			for i, r := range group.Rules {
+				protocol, remote := "any", "any"
+				if r.Protocol != nil {
+					protocol = *r.Protocol
+				}
+				if r.RemoteIpPrefix != nil {
+					remote = *r.RemoteIpPrefix
+				}
+				table[i+1] = []string{r.Id, r.Direction, r.Ethertype, protocol, r.ports(), remote, r.Description}
+			}
+			output.Table(table, output.Format(cmd))
+			return nil
+		},
+	}
+
+	var cmdAdd = &cobra.Command{
+		Use:   "add <security_group>",
+		Short: "Add the rule to the security group",
+		Long: `Add the rule to the security group. Ports are specified as a single port,
+like "22", or as a range, like "8000-8080".`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			rule, err := parseSecurityGroupRule(cmd)
+			if err != nil {
+				return err
+			}
+			path, id, err := resourcePath(cmd, "v1/securitygroups", "security group", args[0])
+			if err != nil {
+				return err
+			}
+
+			var created securityGroupRule
+			body, err := client.Post(path+"/rules", rule, &created)
+			if err != nil {
+				return fmt.Errorf("adding the rule: %w", err)
+			}
+
+			if output.Format(cmd) == output.FmtJSON {
+				fmt.Println(string(body))
+				return nil
+			}
+			fmt.Printf("Rule %s added to security group %s\n", created.Id, id)
+			return nil
+		},
+	}
+	cmdAdd.Flags().String("direction", "ingress", "Traffic direction, 'ingress' or 'egress'")
+	cmdAdd.Flags().String("ethertype", "IPv4", "'IPv4' or 'IPv6'")
+	cmdAdd.Flags().String("protocol", "tcp", "Protocol ('tcp', 'udp', 'icmp' etc.), 'any' matches all protocols")
+	cmdAdd.Flags().String("port", "", "Port or port range (default is any port)")
+	cmdAdd.Flags().String("remote", "", "Remote IP prefix, like '10.0.0.0/8' (default is any address)")
+	cmdAdd.Flags().String("description", "", "Rule description")
+	cmdAdd.RegisterFlagCompletionFunc("direction", cobra.FixedCompletions([]string{"ingress", "egress"}, cobra.ShellCompDirectiveNoFileComp))
+	cmdAdd.RegisterFlagCompletionFunc("ethertype", cobra.FixedCompletions([]string{"IPv4", "IPv6"}, cobra.ShellCompDirectiveNoFileComp))
+
+	var cmdRemove = &cobra.Command{
+		Use:     "remove <rule_id>",
+		Aliases: []string{"rm"},
+		Short:   "Remove the rule from the security group",
+		Args:    cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			path, err := regionPath(cmd, "v1/securitygrouprules", args[0])
+			if err != nil {
+				return err
+			}
+			if !sure.AreYou(cmd, fmt.Sprintf("remove rule %s", args[0])) {
+				return e.ErrAborted
+			}
+
+			if _, err := client.Delete(path, nil, nil); err != nil {
+				return fmt.Errorf("removing the rule: %w", err)
+			}
+			fmt.Printf("Rule %s removed\n", args[0])
+			return nil
+		},
+	}
+
+	cmdRule.AddCommand(cmdList, cmdAdd, cmdRemove)
+	return cmdRule
+}
+
+func parseSecurityGroupRule(cmd *cobra.Command) (*securityGroupRule, error) {
+	flags := cmd.Flags()
+	var rule securityGroupRule
+	var err error
+	if rule.Direction, err = flags.GetString("direction"); err != nil {
+		return nil, err
+	}
+	if rule.Ethertype, err = flags.GetString("ethertype"); err != nil {
+		return nil, err
+	}
+	if rule.Description, err = flags.GetString("description"); err != nil {
+		return nil, err
+	}
+	protocol, err := flags.GetString("protocol")
+	if err != nil {
+		return nil, err
+	}
+	if protocol != "any" {
+		rule.Protocol = &protocol
+	}
+	remote, err := flags.GetString("remote")
+	if err != nil {
+		return nil, err
+	}
+	if remote != "" {
+		rule.RemoteIpPrefix = &remote
+	}
+
+	ports, err := flags.GetString("port")
+	if err != nil {
+		return nil, err
+	}
+	if ports != "" {
+		from, to, isRange := strings.Cut(ports, "-")
+		first, err := strconv.Atoi(from)
+		if err != nil {
+			return nil, fmt.Errorf("invalid port '%s'", ports)
+		}
+		last := first
+		if isRange {
+			if last, err = strconv.Atoi(to); err != nil || last < first {
+				return nil, fmt.Errorf("invalid port range '%s'", ports)
+			}
+		}
+		rule.PortRangeMin, rule.PortRangeMax = &first, &last
+	}
+	return &rule, nil
+}
@@ -0,0 +1,51 @@
+package cloud
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/alecthomas/assert"
+)
+
+func TestParseSecurityGroupRule(t *testing.T) {
+	type TestCase struct {
+		Args     []string
+		Expected string
+		Error    string
+	}
+
+	run := func(tc *TestCase) func(*testing.T) {
+		return func(t *testing.T) {
+			cmd := securityGroupRules()
+			add, _, err := cmd.Find([]string{"add"})
+			assert.NoError(t, err)
+			assert.NoError(t, add.ParseFlags(tc.Args))
+
+			rule, err := parseSecurityGroupRule(add)
+			if tc.Error != "" {
+				assert.EqualError(t, err, tc.Error)
+				return
+			}
+			assert.NoError(t, err)
+			buf, err := json.Marshal(rule)
+			assert.NoError(t, err)
+			assert.Equal(t, tc.Expected, string(buf))
+		}
+	}
+
+	t.Run("defaults", run(&TestCase{
+		Expected: `{"direction":"ingress","ethertype":"IPv4","protocol":"tcp"}`,
+	}))
+	t.Run("single port", run(&TestCase{
+		Args:     []string{"--port", "22", "--remote", "10.0.0.0/8"},
+		Expected: `{"direction":"ingress","ethertype":"IPv4","protocol":"tcp","port_range_min":22,"port_range_max":22,"remote_ip_prefix":"10.0.0.0/8"}`,
+	}))
+	t.Run("port range, any protocol", run(&TestCase{
+		Args:     []string{"--port", "8000-8080", "--protocol", "any", "--direction", "egress"},
+		Expected: `{"direction":"egress","ethertype":"IPv4","port_range_min":8000,"port_range_max":8080}`,
+	}))
+	t.Run("invalid range", run(&TestCase{
+		Args:  []string{"--port", "90-80"},
+		Error: "invalid port range '90-80'",
+	}))
+}
@@ -0,0 +1,300 @@
+package cloud
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	e "github.com/G-core/gcore-cli/internal/errors"
+	"github.com/G-core/gcore-cli/internal/flagutil"
+	"github.com/G-core/gcore-cli/internal/httpcache"
+	"github.com/G-core/gcore-cli/internal/output"
+	"github.com/G-core/gcore-cli/internal/poll"
+	"github.com/G-core/gcore-cli/internal/progress"
+)
+
+const (
+	taskStateNew      = "NEW"
+	taskStateRunning  = "RUNNING"
+	taskStateFinished = "FINISHED"
+	taskStateError    = "ERROR"
+)
+
+type task struct {
+	Id               string         `json:"id"`
+	TaskType         string         `json:"task_type"`
+	State            string         `json:"state"`
+	Error            *string        `json:"error"`
+	CreatedOn        string         `json:"created_on"`
+	FinishedOn       *string        `json:"finished_on"`
+	ProjectId        *int           `json:"project_id"`
+	RegionId         *int           `json:"region_id"`
+	UserId           *int           `json:"user_id"`
+	CreatedResources map[string]any `json:"created_resources"`
+}
+
+// createdIds returns IDs of the resources of the kind, like "instances",
+// created by the task
+func (t *task) createdIds(kind string) []string {
+	list, _ := t.CreatedResources[kind].([]any)
+	var ids []string
+	for _, id := range list {
+		if s, ok := id.(string); ok {
+			ids = append(ids, s)
+		}
+	}
+	return ids
+}
+
+func (t *task) errorMessage() string {
+	if t.Error != nil && *t.Error != "" {
+		return *t.Error
+	}
+	return "unknown error"
+}
+
+func tasks() *cobra.Command {
+	var cmdTask = &cobra.Command{
+		Use:     "task <subcommand>",
+		Aliases: []string{"tasks"},
+		Short:   "Cloud tasks tracking",
+		Long: `Cloud tasks tracking. Commands, that change cloud resources, start
+long-running tasks and report their IDs, unless "--wait" flag is specified.
+These commands show task state and wait for its completion.`,
+		Args: cobra.MinimumNArgs(1),
+	}
+
+	var cmdList = &cobra.Command{
+		Use:     "list",
+		Aliases: []string{"ls"},
+		Short:   "Show list of tasks",
+		Long: `Show list of tasks. Tasks of the project and region are shown, if they
+are specified with "--project" and "--region" flags.`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			query := url.Values{}
+			project, err := resolveProject(cmd)
+			if err != nil {
+				return err
+			}
+			if project != 0 {
+				query.Set("project_id", strconv.Itoa(project))
+			}
+			region, err := resolveRegion(cmd)
+			if err != nil {
+				return err
+			}
+			if region != 0 {
+				query.Set("region_id", strconv.Itoa(region))
+			}
+			if state, _ := cmd.Flags().GetString("state"); state != "" {
+				query.Set("state", strings.ToUpper(state))
+			}
+			limit, err := flagutil.NonNegativeInt(cmd, "limit")
+			if err != nil {
+				return err
+			}
+			if limit > 0 {
+				query.Set("limit", strconv.Itoa(limit))
+			}
+
+			var rsp list[task]
+			body, err := client.Get("/v1/tasks", query, &rsp)
+			if err != nil {
+				return fmt.Errorf("getting the list of tasks: %w", err)
+			}
+
+			if output.Format(cmd) == output.FmtJSON {
+				fmt.Println(string(body))
+				return nil
+			}
+
+			if len(rsp.Results) == 0 {
+				fmt.Printf("you have no tasks\n")
+				return nil
+			}
+
+			table := make([][]string, len(rsp.Results)+1)
+			table[0] = []string{"ID", "Type", "State", "Created", "Finished"}
+			for i, t := range rsp.Results {
+				finished := ""
+				if t.FinishedOn != nil {
+					finished = *t.FinishedOn
+				}
+				table[i+1] = []string{t.Id, t.TaskType, t.State, t.CreatedOn, finished}
+			}
+			output.Table(table, output.Format(cmd))
+			return nil
+		},
+	}
+	cmdList.Flags().String("state", "", "Show tasks in the state (NEW, RUNNING, FINISHED or ERROR)")
+	cmdList.Flags().Int("limit", 0, "Maximum number of tasks to show")
+	cmdList.RegisterFlagCompletionFunc("state", cobra.FixedCompletions(
+		[]string{taskStateNew, taskStateRunning, taskStateFinished, taskStateError},
+		cobra.ShellCompDirectiveNoFileComp,
+	))
+
+	var cmdShow = &cobra.Command{
+		Use:     "show <task_id>",
+		Aliases: []string{"get"},
+		Short:   "Show task details",
+		Args:    cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			var t task
+			body, err := client.Get("/v1/tasks/"+url.PathEscape(args[0]), nil, &t)
+			if err != nil {
+				return fmt.Errorf("getting task details: %w", err)
+			}
+
+			if output.Format(cmd) == output.FmtJSON {
+				fmt.Println(string(body))
+				return nil
+			}
+
+			fmt.Printf("ID:\t   %s\nType:\t   %s\nState:\t   %s\nCreated:   %s\n", t.Id, t.TaskType, t.State, t.CreatedOn)
+			if t.FinishedOn != nil {
+				fmt.Printf("Finished:  %s\n", *t.FinishedOn)
+			}
+			if t.State == taskStateError {
+				fmt.Printf("Error:\t   %s\n", t.errorMessage())
+			}
+			outputCreated(&t)
+			return nil
+		},
+	}
+
+	var cmdWait = &cobra.Command{
+		Use:   "wait <task_id>...",
+		Short: "Wait for the tasks completion",
+		Long: `Wait for the tasks completion. Command fails with the task error message,
+if any of the tasks failed, or if the tasks are not finished in time,
+specified by "--wait-timeout" flag.`,
+		Args: cobra.MinimumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			finished, err := waitForTasks(cmd, args)
+			if err != nil {
+				return err
+			}
+
+			if output.Format(cmd) == output.FmtJSON {
+				output.Print(finished)
+				return nil
+			}
+			for _, t := range finished {
+				fmt.Printf("Task %s finished\n", t.Id)
+				outputCreated(&t)
+			}
+			return nil
+		},
+	}
+
+	cmdTask.AddCommand(cmdList, cmdShow, cmdWait)
+	return cmdTask
+}
+
+// outputCreated shows resources, created by the task
+func outputCreated(t *task) {
+	kinds := make([]string, 0, len(t.CreatedResources))
+	for kind := range t.CreatedResources {
+		if len(t.createdIds(kind)) > 0 {
+			kinds = append(kinds, kind)
+		}
+	}
+	if len(kinds) == 0 {
+		return
+	}
+	sort.Strings(kinds)
+	fmt.Println("Created resources:")
+	for _, kind := range kinds {
+		for _, id := range t.createdIds(kind) {
+			fmt.Printf("\t%s\t%s\n", kind, id)
+		}
+	}
+}
+
+// handleTasks handles the response of API call, that started tasks. Without
+// "--wait" flag started tasks are reported, otherwise command waits for the
+// tasks and calls done with finished tasks to report the result.
+func handleTasks(cmd *cobra.Command, body []byte, tasks taskList, done func(finished []task)) error {
+	wait, err := cmd.Flags().GetBool("wait")
+	if err != nil {
+		return err
+	}
+	if !wait {
+		if output.Format(cmd) == output.FmtJSON {
+			fmt.Println(string(body))
+			return nil
+		}
+		fmt.Printf("Task %s started, use \"cloud task wait\" command to wait for the result\n", strings.Join(tasks.Tasks, ", "))
+		return nil
+	}
+
+	finished, err := waitForTasks(cmd, tasks.Tasks)
+	if err != nil {
+		return err
+	}
+
+	if output.Format(cmd) == output.FmtJSON {
+		output.Print(finished)
+		return nil
+	}
+	done(finished)
+	return nil
+}
+
+// waitForTasks polls the tasks with growing interval until all of them are
+// finished or "--wait-timeout" expires. If any task fails, an error with the
+// task's error message is returned.
+func waitForTasks(cmd *cobra.Command, ids []string) ([]task, error) {
+	timeout, err := cmd.Flags().GetDuration("wait-timeout")
+	if err != nil {
+		return nil, err
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	spinner := progress.StartSpinner(progress.Out(), "Waiting for tasks")
+	defer spinner.Stop()
+
+	start := time.Now()
+	finished := make([]task, 0, len(ids))
+	var poller poll.Poller
+	for len(finished) < len(ids) {
+		id := ids[len(finished)]
+		var t task
+		_, err := client.Do(httpcache.Bypass(ctx), http.MethodGet, "/v1/tasks/"+url.PathEscape(id), nil, nil, &t)
+		if err != nil {
+			return nil, poll.Err(ctx, fmt.Errorf("getting task status: %w", err))
+		}
+
+		switch t.State {
+		case taskStateFinished:
+			finished = append(finished, t)
+			continue
+		case taskStateError:
+			return nil, &e.CliError{
+				Err:  fmt.Errorf("task %s failed: %s", id, t.errorMessage()),
+				Hint: fmt.Sprintf(`See task details with "cloud task show %s" command`, id),
+				Code: 1,
+			}
+		}
+
+		msg := fmt.Sprintf("Task %s (%s) is %s, %s elapsed", id, t.TaskType, strings.ToLower(t.State), time.Since(start).Round(time.Second))
+		if len(ids) > 1 {
+			msg = fmt.Sprintf("[%d/%d] %s", len(finished)+1, len(ids), msg)
+		}
+		spinner.Update(msg)
+
+		if err := poller.Wait(ctx); err != nil {
+			return nil, err
+		}
+	}
+	return finished, nil
+}
@@ -0,0 +1,33 @@
+package cloud
+
+import (
+	"io"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/alecthomas/assert"
+	"github.com/spf13/cobra"
+)
+
+func TestWaitForTasks(t *testing.T) {
+	testClient(t, func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/v1/tasks/ok":
+			io.WriteString(w, `{"id":"ok","state":"FINISHED","created_resources":{"volumes":["v1"],"ports":[{"id":"p"}]}}`)
+		case "/v1/tasks/failed":
+			io.WriteString(w, `{"id":"failed","state":"ERROR","error":"quota exceeded"}`)
+		}
+	})
+	cmd := &cobra.Command{}
+	cmd.Flags().Duration("wait-timeout", time.Minute, "")
+
+	finished, err := waitForTasks(cmd, []string{"ok"})
+	assert.NoError(t, err)
+	assert.Equal(t, 1, len(finished))
+	assert.Equal(t, []string{"v1"}, finished[0].createdIds("volumes"))
+	assert.Equal(t, 0, len(finished[0].createdIds("ports")))
+
+	_, err = waitForTasks(cmd, []string{"ok", "failed"})
+	assert.EqualError(t, err, "task failed failed: quota exceeded")
+}
@@ -0,0 +1,356 @@
+package cloud
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/G-core/gcore-cli/internal/output"
+)
+
+type volumeInfo struct {
+	Id          string `json:"id"`
+	Name        string `json:"name"`
+	Size        int    `json:"size"`
+	Status      string `json:"status"`
+	VolumeType  string `json:"volume_type"`
+	Bootable    bool   `json:"bootable"`
+	CreatedAt   string `json:"created_at"`
+	Attachments []struct {
+		ServerId string `json:"server_id"`
+		Device   string `json:"device"`
+	} `json:"attachments"`
+}
+
+type snapshotInfo struct {
+	Id        string `json:"id"`
+	Name      string `json:"name"`
+	Size      int    `json:"size"`
+	Status    string `json:"status"`
+	VolumeId  string `json:"volume_id"`
+	CreatedAt string `json:"created_at"`
+}
+
+func volume() *cobra.Command {
+	var cmdVolume = &cobra.Command{
+		Use:     "volume <subcommand>",
+		Aliases: []string{"volumes"},
+		Short:   "Block storage volumes management",
+		Long: `Block storage volumes management. Volumes and instances can be specified
+by ID or name.`,
+		Args: cobra.MinimumNArgs(1),
+	}
+
+	var cmdList = &cobra.Command{
+		Use:     "list",
+		Aliases: []string{"ls"},
+		Short:   "Show list of volumes",
+		Args:    cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			path, err := regionPath(cmd, "v1/volumes")
+			if err != nil {
+				return err
+			}
+
+			var rsp list[volumeInfo]
+			body, err := client.Get(path, nil, &rsp)
+			if err != nil {
+				return fmt.Errorf("getting the list of volumes: %w", err)
+			}
+
+			if output.Format(cmd) == output.FmtJSON {
+				fmt.Println(string(body))
+				return nil
+			}
+
+			if len(rsp.Results) == 0 {
+				fmt.Printf("you have no volumes\n")
+				return nil
+			}
+
+			table := make([][]string, len(rsp.Results)+1)
+			table[0] = []string{"ID", "Name", "Size", "Type", "Status", "Attached to"}
+			for i, v := range rsp.Results {
+				var servers []string
+				for _, a := range v.Attachments {
+					servers = append(servers, a.ServerId)
+				}
+				table[i+1] = []string{
+					v.Id,
+					v.Name,
+					strconv.Itoa(v.Size) + " GiB",
+					v.VolumeType,
+					v.Status,
+					strings.Join(servers, ", "),
+				}
+			}
+			output.Table(table, output.Format(cmd))
+			return nil
+		},
+	}
+
+	var cmdCreate = &cobra.Command{
+		Use:   "create",
+		Short: "Create new volume",
+		Long: `Create new volume. Volume is empty, unless it is created from the image
+("--image" flag) or from the snapshot ("--snapshot" flag).`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			path, err := regionPath(cmd, "v1/volumes")
+			if err != nil {
+				return err
+			}
+			flags := cmd.Flags()
+			name, err := flags.GetString("name")
+			if err != nil {
+				return err
+			}
+			size, err := flags.GetInt("size")
+			if err != nil {
+				return err
+			}
+			volType, err := flags.GetString("type")
+			if err != nil {
+				return err
+			}
+			image, err := flags.GetString("image")
+			if err != nil {
+				return err
+			}
+			snapshot, err := flags.GetString("snapshot")
+			if err != nil {
+				return err
+			}
+
+			req := map[string]any{
+				"name":      name,
+				"type_name": volType,
+				"source":    "new-volume",
+			}
+			switch {
+			case image != "":
+				_, imageId, err := imagePath(cmd, image)
+				if err != nil {
+					return err
+				}
+				req["source"] = "image"
+				req["image_id"] = imageId
+			case snapshot != "":
+				snapPath, err := regionPath(cmd, "v1/snapshots")
+				if err != nil {
+					return err
+				}
+				if snapshot, err = resolveId(snapPath, "snapshot", snapshot); err != nil {
+					return err
+				}
+				req["source"] = "snapshot"
+				req["snapshot_id"] = snapshot
+			case size <= 0:
+				return errors.New(`"--size" must be specified for the new empty volume`)
+			}
+			if size > 0 {
+				req["size"] = size
+			}
+
+			var tasks taskList
+			body, err := client.Post(path, req, &tasks)
+			if err != nil {
+				return fmt.Errorf("creating the volume: %w", err)
+			}
+			return handleTasks(cmd, body, tasks, func(finished []task) {
+				for _, t := range finished {
+					for _, id := range t.createdIds("volumes") {
+						fmt.Printf("Volume %s created\n", id)
+					}
+				}
+			})
+		},
+	}
+	cmdCreate.Flags().String("name", "", "Volume name")
+	cmdCreate.Flags().Int("size", 0, "Volume size, GiB (required for the empty volume, default is the size of the image or snapshot)")
+	cmdCreate.Flags().String("type", "standard", "Volume type ('standard', 'ssd_hiiops', 'cold' etc.)")
+	cmdCreate.Flags().String("image", "", "Image ID or name to create the volume from")
+	cmdCreate.Flags().String("snapshot", "", "Snapshot to create the volume from")
+	cmdCreate.MarkFlagRequired("name")
+	cmdCreate.MarkFlagsMutuallyExclusive("image", "snapshot")
+
+	var cmdExtend = &cobra.Command{
+		Use:   "extend <volume>",
+		Short: "Increase the volume size",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			size, err := cmd.Flags().GetInt("size")
+			if err != nil {
+				return err
+			}
+			path, id, err := resourcePath(cmd, "v1/volumes", "volume", args[0])
+			if err != nil {
+				return err
+			}
+
+			var tasks taskList
+			body, err := client.Post(path+"/extend", map[string]int{"size": size}, &tasks)
+			if err != nil {
+				return fmt.Errorf("extending the volume: %w", err)
+			}
+			return handleTasks(cmd, body, tasks, func([]task) {
+				fmt.Printf("Volume %s extended to %d GiB\n", id, size)
+			})
+		},
+	}
+	cmdExtend.Flags().Int("size", 0, "New volume size, GiB")
+	cmdExtend.MarkFlagRequired("size")
+
+	cmdVolume.AddCommand(
+		cmdList,
+		cmdCreate,
+		deleteCommand("v1/volumes", "volume"),
+		volumeAttachment("attach", "Attach the volume to the instance", "attaching", "attached to"),
+		volumeAttachment("detach", "Detach the volume from the instance", "detaching", "detached from"),
+		cmdExtend,
+	)
+	return cmdVolume
+}
+
+// volumeAttachment returns command to attach the volume to the instance or
+// to detach it
+func volumeAttachment(action, short, doing, done string) *cobra.Command {
+	return &cobra.Command{
+		Use:   action + " <volume> <instance>",
+		Short: short,
+		Args:  cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			_, volId, err := resourcePath(cmd, "v1/volumes", "volume", args[0])
+			if err != nil {
+				return err
+			}
+			_, instId, err := instancePath(cmd, args[1])
+			if err != nil {
+				return err
+			}
+			path, err := regionPath(cmd, "v2/volumes", volId, action)
+			if err != nil {
+				return err
+			}
+
+			var tasks taskList
+			body, err := client.Post(path, map[string]string{"instance_id": instId}, &tasks)
+			if err != nil {
+				return fmt.Errorf("%s the volume: %w", doing, err)
+			}
+			return handleTasks(cmd, body, tasks, func([]task) {
+				fmt.Printf("Volume %s %s instance %s\n", volId, done, instId)
+			})
+		},
+	}
+}
+
+func snapshot() *cobra.Command {
+	var cmdSnapshot = &cobra.Command{
+		Use:     "snapshot <subcommand>",
+		Aliases: []string{"snapshots"},
+		Short:   "Volume snapshots management",
+		Args:    cobra.MinimumNArgs(1),
+	}
+
+	var cmdList = &cobra.Command{
+		Use:     "list",
+		Aliases: []string{"ls"},
+		Short:   "Show list of snapshots",
+		Args:    cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			path, err := regionPath(cmd, "v1/snapshots")
+			if err != nil {
+				return err
+			}
+			query := url.Values{}
+			if vol, _ := cmd.Flags().GetString("volume"); vol != "" {
+				volPath, err := regionPath(cmd, "v1/volumes")
+				if err != nil {
+					return err
+				}
+				id, err := resolveId(volPath, "volume", vol)
+				if err != nil {
+					return err
+				}
+				query.Set("volume_id", id)
+			}
+
+			var rsp list[snapshotInfo]
+			body, err := client.Get(path, query, &rsp)
+			if err != nil {
+				return fmt.Errorf("getting the list of snapshots: %w", err)
+			}
+
+			if output.Format(cmd) == output.FmtJSON {
+				fmt.Println(string(body))
+				return nil
+			}
+
+			if len(rsp.Results) == 0 {
+				fmt.Printf("you have no snapshots\n")
+				return nil
+			}
+
+			table := make([][]string, len(rsp.Results)+1)
+			table[0] = []string{"ID", "Name", "Size", "Status", "Volume", "Created"}
+			for i, s := range rsp.Results {
+				table[i+1] = []string{s.Id, s.Name, strconv.Itoa(s.Size) + " GiB", s.Status, s.VolumeId, s.CreatedAt}
+			}
+			output.Table(table, output.Format(cmd))
+			return nil
+		},
+	}
+	cmdList.Flags().String("volume", "", "Show snapshots of the volume")
+
+	var cmdCreate = &cobra.Command{
+		Use:   "create <volume>",
+		Short: "Create the volume snapshot",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			name, err := cmd.Flags().GetString("name")
+			if err != nil {
+				return err
+			}
+			description, err := cmd.Flags().GetString("description")
+			if err != nil {
+				return err
+			}
+			_, volId, err := resourcePath(cmd, "v1/volumes", "volume", args[0])
+			if err != nil {
+				return err
+			}
+			path, err := regionPath(cmd, "v1/snapshots")
+			if err != nil {
+				return err
+			}
+
+			req := map[string]string{"volume_id": volId, "name": name}
+			if description != "" {
+				req["description"] = description
+			}
+			var tasks taskList
+			body, err := client.Post(path, req, &tasks)
+			if err != nil {
+				return fmt.Errorf("creating the snapshot: %w", err)
+			}
+			return handleTasks(cmd, body, tasks, func(finished []task) {
+				for _, t := range finished {
+					for _, id := range t.createdIds("snapshots") {
+						fmt.Printf("Snapshot %s created\n", id)
+					}
+				}
+			})
+		},
+	}
+	cmdCreate.Flags().String("name", "", "Snapshot name")
+	cmdCreate.Flags().String("description", "", "Snapshot description")
+	cmdCreate.MarkFlagRequired("name")
+
+	cmdSnapshot.AddCommand(cmdList, cmdCreate, deleteCommand("v1/snapshots", "snapshot"))
+	return cmdSnapshot
+}
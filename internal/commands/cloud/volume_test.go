@@ -0,0 +1,66 @@
+package cloud
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/alecthomas/assert"
+)
+
+func TestVolumeCreate(t *testing.T) {
+	var req map[string]any
+	testClient(t, func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/v1/images/1/2":
+			io.WriteString(w, `{"count":1,"results":[{"id":"0b6d1a3e-5f5c-4c7e-9a4e-1f2d3c4b5a69","name":"ubuntu"}]}`)
+		case "/v1/volumes/1/2":
+			req = nil
+			assert.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+			io.WriteString(w, `{"tasks":["t1"]}`)
+		}
+	})
+	t.Cleanup(func() { resolvedIds = map[string]int{} })
+
+	type TestCase struct {
+		Args     []string
+		Expected map[string]any
+		Err      string
+	}
+
+	run := func(tc *TestCase) func(*testing.T) {
+		return func(t *testing.T) {
+			req = nil
+			err := testCommand(volume(), append([]string{"volume", "create", "--name", "data"}, tc.Args...)...).Execute()
+			if tc.Err != "" {
+				assert.EqualError(t, err, tc.Err)
+				assert.Zero(t, req)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tc.Expected, req)
+		}
+	}
+
+	t.Run("new volume", run(&TestCase{
+		Args: []string{"--size", "20"},
+		Expected: map[string]any{
+			"name": "data", "type_name": "standard", "source": "new-volume", "size": float64(20),
+		},
+	}))
+	t.Run("new volume without size", run(&TestCase{
+		Err: `"--size" must be specified for the new empty volume`,
+	}))
+	t.Run("from image", run(&TestCase{
+		Args: []string{"--image", "ubuntu", "--type", "ssd_hiiops"},
+		Expected: map[string]any{
+			"name": "data", "type_name": "ssd_hiiops", "source": "image",
+			"image_id": "0b6d1a3e-5f5c-4c7e-9a4e-1f2d3c4b5a69",
+		},
+	}))
+	t.Run("image and snapshot", run(&TestCase{
+		Args: []string{"--image", "ubuntu", "--snapshot", "snap"},
+		Err:  "if any flags in the group [image snapshot] are set none of the others can be; [image snapshot] were all set",
+	}))
+}
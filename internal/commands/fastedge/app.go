@@ -1,18 +1,25 @@
 package fastedge
 
 import (
+	"bytes"
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
+	"maps"
 	"net/http"
+	"os"
 	"strconv"
 	"strings"
 
 	sdk "github.com/G-Core/FastEdge-client-sdk-go"
 	"github.com/spf13/cobra"
 
+	"github.com/G-core/gcore-cli/internal/dotenv"
 	e "github.com/G-core/gcore-cli/internal/errors"
+	"github.com/G-core/gcore-cli/internal/flagutil"
 	"github.com/G-core/gcore-cli/internal/output"
+	"github.com/G-core/gcore-cli/internal/suggest"
 	"github.com/G-core/gcore-cli/internal/sure"
 )
 
@@ -21,7 +28,9 @@ func app() *cobra.Command {
 	var cmdApp = &cobra.Command{
 		Use:   "app <subcommand>",
 		Short: "App-related commands",
-		Args:  cobra.MinimumNArgs(1),
+		Long: `App-related commands. Commands, taking <app_name> argument, accept either
+exact app name or numeric app ID.`,
+		Args: cobra.MinimumNArgs(1),
 	}
 
 	var cmdCreate = &cobra.Command{
@@ -31,13 +40,17 @@ func app() *cobra.Command {
 		Long: `Add new FastEdge app, specifying app properties using flags.
 By default, unless --disabled is specified, app is automatically deployed on all edges.
 You can use either previously-uploaded binary, by specifying "--binary <id>", or
-uploading binary using "--file <filename>". To load file from stdin, use "-" as filename`,
+uploading binary using "--file <filename>". To load file from stdin, use "-" as filename.
+With "--wait" flag, command waits until the binary is compiled and the app is deployed.`,
 		Args: cobra.NoArgs,
 		RunE: func(cmd *cobra.Command, args []string) error {
 			app, err := parseAppProperties(cmd)
 			if err != nil {
 				return err
 			}
+			if app.Status == nil {
+				app.Status = newPointer(appStatusEnabled)
+			}
 			if app.Binary == nil {
 				file, err := cmd.Flags().GetString("file")
 				if err != nil {
@@ -60,6 +73,14 @@ uploading binary using "--file <filename>". To load file from stdin, use "-" as
 			if rsp.StatusCode() != http.StatusOK {
 				return fmt.Errorf("adding the app: %s", extractErrorMessage(rsp.Body))
 			}
+			recordDeployment(rsp.JSON200.Id)
+
+			err = waitFor(cmd, func(ctx context.Context) error {
+				return waitForDeploy(ctx, app, rsp.JSON200.Id)
+			})
+			if err != nil {
+				return err
+			}
 
 			if output.Format(cmd) == output.FmtJSON {
 				fmt.Println(string(rsp.Body))
@@ -84,10 +105,13 @@ uploading binary using "--file <filename>". To load file from stdin, use "-" as
 		Long: `This command allows to change only specified properties of the app,
 omitted properties are left intact. When changing key-value properties, such
 as 'env' and 'rsp_headers', new keys are added to the list, existing keys are
-updated, keys with empty values are deleted.
+updated, keys with empty values are deleted. App status is changed only
+if "--disabled" flag is given, "--disabled=false" enables the app.
 You can use either previously-uploaded binary, by specifying "--binary <id>", or
-uploading binary using "--file <filename>". To load file from stdin, use "-" as filename`,
-		Args: cobra.ExactArgs(1),
+uploading binary using "--file <filename>". To load file from stdin, use "-" as filename.
+With "--wait" flag, command waits until the binary is compiled and the app is deployed.`,
+		Args:              cobra.ExactArgs(1),
+		ValidArgsFunction: completeArgs(completeApps),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			id, err := getAppIdByName(args[0])
 			if err != nil {
@@ -111,18 +135,29 @@ uploading binary using "--file <filename>". To load file from stdin, use "-" as
 					app.Binary = &id
 				}
 			}
+			if app == (sdk.App{}) {
+				return errors.New("nothing to update, please specify app properties to change")
+			}
 
 			if !sure.AreYou(cmd, fmt.Sprintf("update app %d", id)) {
 				return e.ErrAborted
 			}
 
-			rsp, err := client.PatchAppWithResponse(context.Background(), id, app)
+			rsp, err := patchApp(id, app)
 			if err != nil {
 				return fmt.Errorf("updating the app: %w", err)
 			}
 			if rsp.StatusCode() != http.StatusOK {
 				return fmt.Errorf("updating the app: %s", extractErrorMessage(rsp.Body))
 			}
+			recordDeployment(id)
+
+			err = waitFor(cmd, func(ctx context.Context) error {
+				return waitForDeploy(ctx, app, id)
+			})
+			if err != nil {
+				return err
+			}
 
 			if output.Format(cmd) == output.FmtJSON {
 				fmt.Println(string(rsp.Body))
@@ -145,9 +180,21 @@ uploading binary using "--file <filename>". To load file from stdin, use "-" as
 		Use:     "list",
 		Aliases: []string{"ls"},
 		Short:   "Show list of client's apps",
-		Args:    cobra.NoArgs,
+		Long: `Show list of client's apps. Apps can be filtered by name (partial match),
+status and binary, sorted by "--sort-by" field (prefix the field with "-"
+for descending order) and paged using "--limit" and "--offset" flags.`,
+		Args: cobra.NoArgs,
 		RunE: func(cmd *cobra.Command, args []string) error {
-			rsp, err := client.ListAppsWithResponse(context.Background(), &sdk.ListAppsParams{})
+			params, err := parseListAppsParams(cmd)
+			if err != nil {
+				return err
+			}
+			wide, err := cmd.Flags().GetBool("wide")
+			if err != nil {
+				return err
+			}
+
+			rsp, err := client.ListAppsWithResponse(context.Background(), params)
 			if err != nil {
 				return fmt.Errorf("getting the list of apps: %w", err)
 			}
@@ -167,6 +214,9 @@ uploading binary using "--file <filename>". To load file from stdin, use "-" as
 
 			table := make([][]string, len(rsp.JSON200.Apps)+1)
 			table[0] = []string{"ID", "Status", "Name", "Url"}
+			if wide {
+				table[0] = append(table[0], "Plan", "Binary", "Log until")
+			}
 			for i, app := range rsp.JSON200.Apps {
 				table[i+1] = []string{
 					strconv.FormatInt(app.Id, 10),
@@ -174,11 +224,39 @@ uploading binary using "--file <filename>". To load file from stdin, use "-" as
 					app.Name,
 					unrefString(app.Url),
 				}
+				if wide {
+					debugUntil := ""
+					if app.DebugUntil != nil {
+						debugUntil = app.DebugUntil.UTC().Format("2006-01-02T15:04:05")
+					}
+					table[i+1] = append(table[i+1], app.Plan, strconv.FormatInt(app.Binary, 10), debugUntil)
+				}
 			}
 			output.Table(table, output.Format(cmd))
+
+			count := rsp.JSON200.Count
+			if output.Format(cmd) == output.FmtHuman && count != nil && *count > len(rsp.JSON200.Apps) {
+				offset := 0
+				if params.Offset != nil {
+					offset = *params.Offset
+				}
+				fmt.Printf("Shown apps %d-%d of %d\n", offset+1, offset+len(rsp.JSON200.Apps), *count)
+			}
 			return nil
 		},
 	}
+	cmdList.Flags().String("name", "", "Show apps with names containing the string")
+	cmdList.Flags().String("status", "", `Show apps with the status ("enabled", "disabled" etc.)`)
+	cmdList.Flags().Int64("binary", 0, "Show apps using the binary")
+	cmdList.Flags().String("sort-by", "", "Sort by field: name, status, id, binary, plan or template, '-' prefix means descending order")
+	cmdList.Flags().Int("limit", 0, "Maximum number of apps to show")
+	cmdList.Flags().Int("offset", 0, "Number of apps to skip")
+	cmdList.Flags().Bool("wide", false, "Show more app properties")
+	cmdList.RegisterFlagCompletionFunc("binary", completeBinaries)
+	cmdList.RegisterFlagCompletionFunc("sort-by", cobra.FixedCompletions(
+		[]string{"name", "-name", "status", "-status", "id", "-id", "binary", "-binary", "plan", "-plan", "template", "-template"},
+		cobra.ShellCompDirectiveNoFileComp,
+	))
 
 	var cmdGet = &cobra.Command{
 		Use:     "show <app_name>",
@@ -186,8 +264,10 @@ uploading binary using "--file <filename>". To load file from stdin, use "-" as
 		Short:   "Show app details",
 		Long: `Show app properties. This command doesn't show app call statistics.
 To see statistics, use "fastedge stats app_calls" and "fastedge stats app_duration"
-commands.`,
-		Args: cobra.ExactArgs(1),
+commands.
+Values of environment variables are masked, unless "--show-secrets" is specified.`,
+		Args:              cobra.ExactArgs(1),
+		ValidArgsFunction: completeArgs(completeApps),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			id, err := getAppIdByName(args[0])
 			if err != nil {
@@ -220,109 +300,103 @@ commands.`,
 			if rsp.JSON200.DebugUntil != nil {
 				fmt.Printf("Log until: %v\n", *rsp.JSON200.DebugUntil)
 			}
-			outputMap(rsp.JSON200.Env, "Env")
+			showSecrets, err := cmd.Flags().GetBool("show-secrets")
+			if err != nil {
+				return err
+			}
+			env := rsp.JSON200.Env
+			if !showSecrets {
+				env = maskValues(env)
+			}
+			outputMap(env, "Env")
 			outputMap(rsp.JSON200.RspHeaders, "Response headers")
 			return nil
 		},
 	}
 
+	cmdGet.Flags().Bool("show-secrets", false, "Show environment variable values")
+
 	var cmdEnable = &cobra.Command{
-		Use:   "enable <app_name>",
-		Short: "Enable the app",
-		Args:  cobra.ExactArgs(1),
+		Use:   "enable <app_name>...",
+		Short: "Enable the apps",
+		Long: `Enable the apps, specified by names, or selected with "--all", "--selector"
+and "--status" flags.`,
+		Args:              cobra.ArbitraryArgs,
+		ValidArgsFunction: completeApps,
 		RunE: func(cmd *cobra.Command, args []string) error {
-			id, err := getAppIdByName(args[0])
-			if err != nil {
-				return fmt.Errorf("cannot find app by name: %w", err)
-			}
-			rsp, err := client.PatchAppWithResponse(
-				context.Background(),
-				id,
-				sdk.App{Status: newPointer(1)},
-			)
-			if err != nil {
-				return fmt.Errorf("enabling app: %w", err)
-			}
-			if rsp.StatusCode() != http.StatusOK {
-				return fmt.Errorf("enabling app: %s", extractErrorMessage(rsp.Body))
-			}
-
-			if output.Format(cmd) == output.FmtJSON {
-				fmt.Println(string(rsp.Body))
-				return nil
-			}
-
-			fmt.Printf("App %d enabled\n", id)
-			return nil
+			return runBulk(cmd, args, bulkAction{
+				verb: "enable",
+				done: "enabled",
+				run: func(id int64) ([]byte, error) {
+					rsp, err := patchApp(id, sdk.App{Status: newPointer(appStatusEnabled)})
+					if err != nil {
+						return nil, fmt.Errorf("enabling app: %w", err)
+					}
+					if rsp.StatusCode() != http.StatusOK {
+						return nil, fmt.Errorf("enabling app: %s", extractErrorMessage(rsp.Body))
+					}
+					return rsp.Body, nil
+				},
+			})
 		},
 	}
+	bulkFlags(cmdEnable)
 
 	var cmdDisable = &cobra.Command{
-		Use:   "disable <app_name>",
-		Short: "Disable the app",
-		Args:  cobra.ExactArgs(1),
+		Use:   "disable <app_name>...",
+		Short: "Disable the apps",
+		Long: `Disable the apps, specified by names, or selected with "--all", "--selector"
+and "--status" flags.`,
+		Args:              cobra.ArbitraryArgs,
+		ValidArgsFunction: completeApps,
 		RunE: func(cmd *cobra.Command, args []string) error {
-			id, err := getAppIdByName(args[0])
-			if err != nil {
-				return fmt.Errorf("cannot find app by name: %w", err)
-			}
-			rsp, err := client.PatchAppWithResponse(
-				context.Background(),
-				id,
-				sdk.App{Status: newPointer(2)},
-			)
-			if err != nil {
-				return fmt.Errorf("disabling app: %w", err)
-			}
-			if rsp.StatusCode() != http.StatusOK {
-				return fmt.Errorf("disabling app: %s", extractErrorMessage(rsp.Body))
-			}
-
-			if output.Format(cmd) == output.FmtJSON {
-				fmt.Println(string(rsp.Body))
-				return nil
-			}
-
-			fmt.Printf("App %d disabled\n", id)
-			return nil
+			return runBulk(cmd, args, bulkAction{
+				verb: "disable",
+				done: "disabled",
+				run: func(id int64) ([]byte, error) {
+					rsp, err := patchApp(id, sdk.App{Status: newPointer(appStatusOff)})
+					if err != nil {
+						return nil, fmt.Errorf("disabling app: %w", err)
+					}
+					if rsp.StatusCode() != http.StatusOK {
+						return nil, fmt.Errorf("disabling app: %s", extractErrorMessage(rsp.Body))
+					}
+					return rsp.Body, nil
+				},
+			})
 		},
 	}
+	bulkFlags(cmdDisable)
 
 	var cmdDelete = &cobra.Command{
-		Use:     "delete <app_name>",
-		Short:   "Delete the app",
+		Use:     "delete <app_name>...",
+		Short:   "Delete the apps",
 		Aliases: []string{"rm"},
-		Long: `This command deletes the app. The binary, referenced by the app, is not deleted,
+		Long: `This command deletes the apps, specified by names, or selected with "--all",
+"--selector" and "--status" flags. The binary, referenced by the app, is not deleted,
 however binaries, not referenced by any app, get deleted by cleanup process regularly,
 so if you don't want this to happen, consider disabling the app to keep binary referenced`,
-		Args: cobra.ExactArgs(1),
+		Args:              cobra.ArbitraryArgs,
+		ValidArgsFunction: completeApps,
 		RunE: func(cmd *cobra.Command, args []string) error {
-			id, err := getAppIdByName(args[0])
-			if err != nil {
-				return fmt.Errorf("cannot find app by name: %w", err)
-			}
-
-			if !sure.AreYou(cmd, fmt.Sprintf("delete app %d", id)) {
-				return e.ErrAborted
-			}
-
-			rsp, err := client.DelAppWithResponse(context.Background(), id)
-			if err != nil {
-				return fmt.Errorf("deleting app: %w", err)
-			}
-			if rsp.StatusCode() != http.StatusOK {
-				return fmt.Errorf("deleting app: %s", extractErrorMessage(rsp.Body))
-			}
-
-			if output.Format(cmd) == output.FmtJSON {
-				fmt.Println(string(rsp.Body))
-				return nil
-			}
-
-			fmt.Printf("App %d deleted\n", id)
-			return nil
+			return runBulk(cmd, args, bulkAction{
+				verb:    "delete",
+				done:    "deleted",
+				confirm: true,
+				run: func(id int64) ([]byte, error) {
+					rsp, err := client.DelAppWithResponse(context.Background(), id)
+					if err != nil {
+						return nil, fmt.Errorf("deleting app: %w", err)
+					}
+					if rsp.StatusCode() != http.StatusOK {
+						return nil, fmt.Errorf("deleting app: %s", extractErrorMessage(rsp.Body))
+					}
+					return rsp.Body, nil
+				},
+			})
 		},
 	}
+	bulkFlags(cmdDelete)
 
 	cmdApp.AddCommand(
 		cmdList,
@@ -332,6 +406,16 @@ so if you don't want this to happen, consider disabling the app to keep binary r
 		cmdCreate,
 		cmdUpdate,
 		cmdDelete,
+		appHistory(),
+		appRollback(),
+		appPromote(),
+		appClone(),
+		appRename(),
+		appEnv(),
+		appHeaders(),
+		appInit(),
+		appCurl(),
+		appOpen(),
 	)
 	return cmdApp
 }
@@ -339,16 +423,22 @@ so if you don't want this to happen, consider disabling the app to keep binary r
 func appPropertiesFlags(cmd *cobra.Command) {
 	cmd.Flags().String("name", "", "App name")
 	cmd.Flags().Int64("binary", 0, "Wasm binary id")
+	cmd.RegisterFlagCompletionFunc("binary", completeBinaries)
 	cmd.Flags().String("file", "", "Wasm binary filename ('-' means stdin)")
-	cmd.Flags().Bool("disabled", false, "Set status to 'disabled'")
+	cmd.Flags().Bool("disabled", false, "Set status to 'disabled' ('--disabled=false' sets it to 'enabled')")
 	cmd.Flags().StringArray("env", nil, "Environment, in name=value format")
+	cmd.Flags().String("env-file", "", "Read environment from file in dotenv format")
+	cmd.Flags().Bool("env-from-stdin", false, "Read environment from stdin in dotenv format")
 	cmd.Flags().StringSlice("rsp_headers", nil, "Response headers to add, in name=value format")
 }
 
+// parseAppProperties fills only the app fields, specified by the flags, so
+// the result can be used both as new app and as PATCH body for existing app
 func parseAppProperties(cmd *cobra.Command) (sdk.App, error) {
 	var app sdk.App
+	flags := cmd.Flags()
 
-	name, err := cmd.Flags().GetString("name")
+	name, err := flags.GetString("name")
 	if err != nil {
 		return app, err
 	}
@@ -356,7 +446,7 @@ func parseAppProperties(cmd *cobra.Command) (sdk.App, error) {
 		app.Name = &name
 	}
 
-	binID, err := cmd.Flags().GetInt64("binary")
+	binID, err := flags.GetInt64("binary")
 	if err != nil {
 		return app, err
 	}
@@ -364,29 +454,140 @@ func parseAppProperties(cmd *cobra.Command) (sdk.App, error) {
 		app.Binary = &binID
 	}
 
-	disabled, err := cmd.Flags().GetBool("disabled")
+	if flags.Changed("disabled") {
+		disabled, err := flags.GetBool("disabled")
+		if err != nil {
+			return app, err
+		}
+		if disabled {
+			app.Status = newPointer(appStatusOff)
+		} else {
+			app.Status = newPointer(appStatusEnabled)
+		}
+	}
+
+	if flags.Changed("env") || flags.Changed("env-file") || flags.Changed("env-from-stdin") {
+		env, err := parseEnv(cmd)
+		if err != nil {
+			return app, err
+		}
+		app.Env = &env
+	}
+
+	if flags.Changed("rsp_headers") {
+		rspHeaders, err := getMapParamP("rsp_headers", flags.GetStringSlice)
+		if err != nil {
+			return app, err
+		}
+		app.RspHeaders = &rspHeaders
+	}
+
+	return app, nil
+}
+
+// parseEnv collects environment from "--env-file", "--env-from-stdin" and
+// "--env" flags, in this order, so later sources override earlier ones
+func parseEnv(cmd *cobra.Command) (map[string]string, error) {
+	env := make(map[string]string)
+
+	envFile, err := cmd.Flags().GetString("env-file")
 	if err != nil {
-		return app, err
+		return nil, err
 	}
-	if disabled {
-		app.Status = newPointer(2)
-	} else {
-		app.Status = newPointer(1)
+	if envFile != "" {
+		f, err := os.Open(envFile)
+		if err != nil {
+			return nil, fmt.Errorf("cannot open %s: %w", envFile, err)
+		}
+		defer f.Close()
+		vars, err := dotenv.Parse(f)
+		if err != nil {
+			return nil, fmt.Errorf("cannot parse %s: %w", envFile, err)
+		}
+		maps.Copy(env, vars)
 	}
 
-	env, err := getMapParamP("env", cmd.Flags().GetStringArray)
+	fromStdin, err := cmd.Flags().GetBool("env-from-stdin")
 	if err != nil {
-		return app, err
+		return nil, err
+	}
+	if fromStdin {
+		if file, _ := cmd.Flags().GetString("file"); file == sourceStdin {
+			return nil, errors.New("cannot read both binary and environment from stdin")
+		}
+		vars, err := dotenv.Parse(os.Stdin)
+		if err != nil {
+			return nil, fmt.Errorf("cannot parse environment from stdin: %w", err)
+		}
+		maps.Copy(env, vars)
 	}
-	app.Env = &env
 
-	rspHeaders, err := getMapParamP("rsp_headers", cmd.Flags().GetStringSlice)
+	vars, err := getMapParamP("env", cmd.Flags().GetStringArray)
 	if err != nil {
-		return app, err
+		return nil, err
 	}
-	app.RspHeaders = &rspHeaders
+	maps.Copy(env, vars)
 
-	return app, nil
+	return env, nil
+}
+
+func parseListAppsParams(cmd *cobra.Command) (*sdk.ListAppsParams, error) {
+	var params sdk.ListAppsParams
+	flags := cmd.Flags()
+
+	name, err := flags.GetString("name")
+	if err != nil {
+		return nil, err
+	}
+	if name != "" {
+		params.Name = &name
+	}
+
+	status, err := flags.GetString("status")
+	if err != nil {
+		return nil, err
+	}
+	if status != "" {
+		code, err := parseAppStatus(status)
+		if err != nil {
+			return nil, err
+		}
+		params.Status = &code
+	}
+
+	binID, err := flags.GetInt64("binary")
+	if err != nil {
+		return nil, err
+	}
+	if binID != 0 {
+		params.Binary = &binID
+	}
+
+	sortBy, err := flags.GetString("sort-by")
+	if err != nil {
+		return nil, err
+	}
+	if sortBy != "" {
+		params.Ordering = newPointer(sdk.ListAppsParamsOrdering(sortBy))
+	}
+
+	limit, err := flagutil.NonNegativeInt(cmd, "limit")
+	if err != nil {
+		return nil, err
+	}
+	if limit > 0 {
+		params.Limit = &limit
+	}
+
+	offset, err := flagutil.NonNegativeInt(cmd, "offset")
+	if err != nil {
+		return nil, err
+	}
+	if offset > 0 {
+		params.Offset = &offset
+	}
+
+	return &params, nil
 }
 
 func getMapParamP(name string, f func(name string) ([]string, error)) (map[string]string, error) {
@@ -434,36 +635,120 @@ func outputMap(m *map[string]string, title string) {
 	}
 }
 
+// maskValues hides values of key-value property, keeping the keys
+func maskValues(m *map[string]string) *map[string]string {
+	if m == nil {
+		return nil
+	}
+	masked := make(map[string]string, len(*m))
+	for k := range *m {
+		masked[k] = "********"
+	}
+	return &masked
+}
+
+// getAppIdByName resolves app name to app ID. Exact name match is required,
+// if there is no app with such name and appName is a number, it is treated
+// as app ID.
 func getAppIdByName(appName string) (int64, error) {
-	idRsp, err := client.ListAppsWithResponse(context.Background(), &sdk.ListAppsParams{Name: &appName})
+	// name filter matches partially, so look for exact match on all pages
+	candidates, err := listAllApps(sdk.ListAppsParams{Name: &appName})
 	if err != nil {
-		return 0, fmt.Errorf("api response: %w", err)
+		return 0, err
 	}
-	if idRsp.StatusCode() != http.StatusOK {
-		return 0, fmt.Errorf("%s", string(idRsp.Body))
+	for _, app := range candidates {
+		if app.Name == appName {
+			return app.Id, nil
+		}
 	}
-	if idRsp.JSON200 == nil {
-		return 0, fmt.Errorf("app '%s' not found", appName)
+
+	// numeric argument is an app ID, if such app exists
+	if id, err := strconv.ParseInt(appName, 10, 64); err == nil && id > 0 {
+		rsp, err := client.GetAppWithResponse(context.Background(), id)
+		if err != nil {
+			return 0, fmt.Errorf("api response: %w", err)
+		}
+		switch rsp.StatusCode() {
+		case http.StatusOK:
+			return id, nil
+		case http.StatusNotFound:
+		default:
+			return 0, fmt.Errorf("%s", extractErrorMessage(rsp.Body))
+		}
 	}
-	if len(idRsp.JSON200.Apps) != 1 {
-		return 0, fmt.Errorf("app '%s' not found", appName)
+
+	switch len(candidates) {
+	case 0:
+		return 0, appNotFound(appName)
+	case 1:
+		return 0, &e.CliError{
+			Err:  fmt.Errorf("app '%s' not found", appName),
+			Hint: fmt.Sprintf("Did you mean '%s'?", candidates[0].Name),
+			Code: 1,
+		}
+	}
+
+	lines := make([]string, len(candidates))
+	for i, app := range candidates {
+		lines[i] = fmt.Sprintf("%d\t%s", app.Id, app.Name)
+	}
+	return 0, &e.CliError{
+		Err:     fmt.Errorf("app name '%s' is ambiguous, it matches %d apps", appName, len(candidates)),
+		Details: "matching apps:\n" + strings.Join(lines, "\n"),
+		Hint:    "Specify exact app name or app ID",
+		Code:    1,
 	}
-	return idRsp.JSON200.Apps[0].Id, nil
 }
 
-func getAppByName(appName string) (sdk.AppShort, error) {
-	idRsp, err := client.ListAppsWithResponse(context.Background(), &sdk.ListAppsParams{Name: &appName})
+// appNotFound returns "not found" error, suggesting similar app names
+func appNotFound(appName string) error {
+	cliErr := &e.CliError{
+		Err:  fmt.Errorf("app '%s' not found", appName),
+		Code: 1,
+	}
+
+	rsp, err := client.ListAppsWithResponse(context.Background(), &sdk.ListAppsParams{})
+	if err != nil || rsp.StatusCode() != http.StatusOK || rsp.JSON200 == nil {
+		return cliErr
+	}
+	names := make([]string, len(rsp.JSON200.Apps))
+	for i, app := range rsp.JSON200.Apps {
+		names[i] = app.Name
+	}
+	if similar := suggest.Closest(appName, names, 3); len(similar) > 0 {
+		cliErr.Hint = "Did you mean '" + strings.Join(similar, "', '") + "'?"
+	}
+	return cliErr
+}
+
+func getApp(id int64) (*sdk.App, error) {
+	rsp, err := client.GetAppWithResponse(context.Background(), id)
 	if err != nil {
-		return sdk.AppShort{}, fmt.Errorf("api response: %w", err)
+		return nil, fmt.Errorf("getting app details: %w", err)
 	}
-	if idRsp.StatusCode() != http.StatusOK {
-		return sdk.AppShort{}, fmt.Errorf("%s", string(idRsp.Body))
+	if rsp.StatusCode() != http.StatusOK {
+		return nil, fmt.Errorf("getting app details: %s", extractErrorMessage(rsp.Body))
 	}
-	if idRsp.JSON200 == nil {
-		return sdk.AppShort{}, fmt.Errorf("app '%s' not found", appName)
+	return rsp.JSON200, nil
+}
+
+// patchApp updates only the app fields, that are set. SDK App has "log"
+// field without "omitempty", so it is removed from the request body, unless
+// set, otherwise every update would reset the app logging channel.
+func patchApp(id int64, app sdk.App) (*sdk.PatchAppResponse, error) {
+	buf, err := json.Marshal(app)
+	if err != nil {
+		return nil, err
 	}
-	if len(idRsp.JSON200.Apps) != 1 {
-		return sdk.AppShort{}, fmt.Errorf("app '%s' not found", appName)
+	if app.Log == nil {
+		var body map[string]json.RawMessage
+		if err := json.Unmarshal(buf, &body); err != nil {
+			return nil, err
+		}
+		delete(body, "log")
+		if buf, err = json.Marshal(body); err != nil {
+			return nil, err
+		}
 	}
-	return idRsp.JSON200.Apps[0], nil
+	return client.PatchAppWithBodyWithResponse(context.Background(), id, "application/json", bytes.NewReader(buf))
 }
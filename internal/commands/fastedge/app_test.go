@@ -0,0 +1,216 @@
+package fastedge
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strconv"
+	"testing"
+
+	sdk "github.com/G-Core/FastEdge-client-sdk-go"
+	"github.com/alecthomas/assert"
+	"github.com/spf13/cobra"
+
+	e "github.com/G-core/gcore-cli/internal/errors"
+)
+
+func TestParseAppPropertiesPatchBody(t *testing.T) {
+	var body string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		buf, _ := io.ReadAll(r.Body)
+		body = string(buf)
+		w.Header().Set("Content-Type", "application/json")
+		io.WriteString(w, `{"id":1,"name":"app","status":1,"binary":1,"plan":"basic","api_type":"wasi-http"}`)
+	}))
+	defer srv.Close()
+
+	var err error
+	client, err = sdk.NewClientWithResponses(srv.URL)
+	assert.NoError(t, err)
+
+	envFile := filepath.Join(t.TempDir(), ".env")
+	assert.NoError(t, os.WriteFile(envFile, []byte("A=from_file\nB='quoted value'\n"), 0600))
+
+	type TestCase struct {
+		Args     []string
+		Expected string
+	}
+
+	run := func(tc *TestCase) func(*testing.T) {
+		return func(t *testing.T) {
+			cmd := &cobra.Command{}
+			appPropertiesFlags(cmd)
+			assert.NoError(t, cmd.ParseFlags(tc.Args))
+
+			app, err := parseAppProperties(cmd)
+			assert.NoError(t, err)
+
+			_, err = patchApp(1, app)
+			assert.NoError(t, err)
+			assert.Equal(t, tc.Expected, body)
+		}
+	}
+
+	// "log" field has no "omitempty" in SDK, but it is not sent unless set
+	t.Run("no flags", run(&TestCase{
+		Args:     nil,
+		Expected: `{}`,
+	}))
+	t.Run("name", run(&TestCase{
+		Args:     []string{"--name", "new"},
+		Expected: `{"name":"new"}`,
+	}))
+	t.Run("binary", run(&TestCase{
+		Args:     []string{"--binary", "42"},
+		Expected: `{"binary":42}`,
+	}))
+	t.Run("env does not touch status", run(&TestCase{
+		Args:     []string{"--env", "X=1"},
+		Expected: `{"env":{"X":"1"}}`,
+	}))
+	t.Run("disabled", run(&TestCase{
+		Args:     []string{"--disabled"},
+		Expected: `{"status":2}`,
+	}))
+	t.Run("disabled=false enables", run(&TestCase{
+		Args:     []string{"--disabled=false"},
+		Expected: `{"status":1}`,
+	}))
+	t.Run("delete env key", run(&TestCase{
+		Args:     []string{"--env", "X="},
+		Expected: `{"env":{"X":""}}`,
+	}))
+	t.Run("env file with overrides", run(&TestCase{
+		Args:     []string{"--env-file", envFile, "--env", "A=from_flag"},
+		Expected: `{"env":{"A":"from_flag","B":"quoted value"}}`,
+	}))
+	t.Run("response headers", run(&TestCase{
+		Args:     []string{"--rsp_headers", "X-One=1", "--rsp_headers", "X-Two=2"},
+		Expected: `{"rsp_headers":{"X-One":"1","X-Two":"2"}}`,
+	}))
+	t.Run("all together", run(&TestCase{
+		Args:     []string{"--name", "n", "--binary", "7", "--disabled", "--env", "K=V", "--rsp_headers", "H=1"},
+		Expected: `{"binary":7,"env":{"K":"V"},"name":"n","rsp_headers":{"H":"1"},"status":2}`,
+	}))
+}
+
+func TestGetAppIdByName(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/v1/apps/42":
+			io.WriteString(w, `{"id":42,"name":"answer","status":1,"binary":1,"plan":"basic","api_type":"wasi-http"}`)
+			return
+		case "/v1/apps/43":
+			w.WriteHeader(http.StatusNotFound)
+			io.WriteString(w, `{"error":"app not found"}`)
+			return
+		}
+		switch r.URL.Query().Get("name") {
+		case "foo":
+			io.WriteString(w, `{"apps":[{"id":1,"name":"foo"},{"id":2,"name":"foo-canary"}]}`)
+		case "fo":
+			io.WriteString(w, `{"apps":[{"id":1,"name":"foo"},{"id":2,"name":"foo-canary"}]}`)
+		case "foo-can":
+			io.WriteString(w, `{"apps":[{"id":2,"name":"foo-canary"}]}`)
+		case "":
+			io.WriteString(w, `{"apps":[{"id":1,"name":"foo"},{"id":2,"name":"foo-canary"},{"id":3,"name":"bar"}]}`)
+		default:
+			io.WriteString(w, `{"apps":[]}`)
+		}
+	}))
+	defer srv.Close()
+
+	var err error
+	client, err = sdk.NewClientWithResponses(srv.URL)
+	assert.NoError(t, err)
+
+	id, err := getAppIdByName("foo")
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1), id)
+
+	id, err = getAppIdByName("42")
+	assert.NoError(t, err)
+	assert.Equal(t, int64(42), id)
+
+	var cliErr *e.CliError
+	_, err = getAppIdByName("43")
+	assert.True(t, errors.As(err, &cliErr))
+	assert.Equal(t, "app '43' not found", cliErr.Error())
+
+	_, err = getAppIdByName("fo")
+	assert.True(t, errors.As(err, &cliErr))
+	assert.Equal(t, "app name 'fo' is ambiguous, it matches 2 apps", cliErr.Error())
+	assert.Equal(t, "matching apps:\n1\tfoo\n2\tfoo-canary", cliErr.Details)
+
+	_, err = getAppIdByName("foo-can")
+	assert.True(t, errors.As(err, &cliErr))
+	assert.Equal(t, "Did you mean 'foo-canary'?", cliErr.Hint)
+
+	_, err = getAppIdByName("baz")
+	assert.True(t, errors.As(err, &cliErr))
+	assert.Equal(t, "app 'baz' not found", cliErr.Error())
+	assert.Equal(t, "Did you mean 'bar'?", cliErr.Hint)
+}
+
+func TestGetAppIdByNamePaged(t *testing.T) {
+	// partial name matches span several pages, exact match is on the last one
+	var apps []sdk.AppShort
+	for i := range appsPageSize + 10 {
+		apps = append(apps, sdk.AppShort{Id: int64(i + 1), Name: "foo-" + strconv.Itoa(i+1)})
+	}
+	apps = append(apps, sdk.AppShort{Id: 1000, Name: "foo"})
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		query := r.URL.Query()
+		assert.Equal(t, "foo", query.Get("name"))
+		offset, _ := strconv.Atoi(query.Get("offset"))
+		limit, _ := strconv.Atoi(query.Get("limit"))
+		page := apps[min(offset, len(apps)):min(offset+limit, len(apps))]
+		json.NewEncoder(w).Encode(map[string]any{"apps": page, "count": len(apps)})
+	}))
+	defer srv.Close()
+
+	var err error
+	client, err = sdk.NewClientWithResponses(srv.URL)
+	assert.NoError(t, err)
+
+	id, err := getAppIdByName("foo")
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1000), id)
+}
+
+func TestWaitForDeploy(t *testing.T) {
+	var status string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		io.WriteString(w, `{"id":1,"name":"app","status":`+status+`,"binary":1,"plan":"basic","api_type":"wasi-http"}`)
+	}))
+	defer srv.Close()
+
+	var err error
+	client, err = sdk.NewClientWithResponses(srv.URL)
+	assert.NoError(t, err)
+
+	enable := sdk.App{Status: newPointer(appStatusEnabled)}
+	unchanged := sdk.App{Env: &map[string]string{"X": "1"}}
+
+	status = "1"
+	assert.NoError(t, waitForDeploy(context.Background(), enable, 1))
+	assert.NoError(t, waitForDeploy(context.Background(), unchanged, 1))
+
+	// app, which is being enabled, must not stay disabled
+	status = "2"
+	err = waitForDeploy(context.Background(), enable, 1)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "app 1 is disabled")
+
+	// updating already disabled app leaves it disabled
+	assert.NoError(t, waitForDeploy(context.Background(), unchanged, 1))
+}
@@ -1,16 +1,23 @@
 package fastedge
 
 import (
+	"cmp"
 	"context"
 	"errors"
 	"fmt"
+	"io"
 	"net/http"
 	"os"
+	"slices"
 	"strconv"
+	"strings"
 
+	sdk "github.com/G-Core/FastEdge-client-sdk-go"
 	"github.com/spf13/cobra"
 
+	"github.com/G-core/gcore-cli/internal/flagutil"
 	"github.com/G-core/gcore-cli/internal/output"
+	"github.com/G-core/gcore-cli/internal/progress"
 )
 
 const (
@@ -29,8 +36,33 @@ func binary() *cobra.Command {
 		Use:     "list",
 		Aliases: []string{"ls"},
 		Short:   "Show list of client's binaries",
-		Args:    cobra.NoArgs,
+		Long: `Show list of client's binaries. Binaries can be filtered by status,
+sorted by "--sort-by" field (prefix the field with "-" for descending order)
+and paged using "--limit" and "--offset" flags.`,
+		Args: cobra.NoArgs,
 		RunE: func(cmd *cobra.Command, args []string) error {
+			flags := cmd.Flags()
+			status, err := flags.GetString("status")
+			if err != nil {
+				return err
+			}
+			sortBy, err := flags.GetString("sort-by")
+			if err != nil {
+				return err
+			}
+			limit, err := flagutil.NonNegativeInt(cmd, "limit")
+			if err != nil {
+				return err
+			}
+			offset, err := flagutil.NonNegativeInt(cmd, "offset")
+			if err != nil {
+				return err
+			}
+			wide, err := flags.GetBool("wide")
+			if err != nil {
+				return err
+			}
+
 			rsp, err := client.ListBinariesWithResponse(context.Background())
 			if err != nil {
 				return fmt.Errorf("getting the list of binaries: %w", err)
@@ -39,37 +71,76 @@ func binary() *cobra.Command {
 				return fmt.Errorf("getting the list of binaries: %s", extractErrorMessage(rsp.Body))
 			}
 
+			// API doesn't support filtering and paging of binaries, so it is done here
+			binaries, err := filterBinaries(rsp.JSON200.Binaries, status, sortBy)
+			if err != nil {
+				return err
+			}
+			total := len(binaries)
+			binaries = binaries[min(offset, total):]
+			if limit > 0 && limit < len(binaries) {
+				binaries = binaries[:limit]
+			}
+
 			if output.Format(cmd) == output.FmtJSON {
-				fmt.Println(string(rsp.Body))
+				if status == "" && sortBy == "" && limit == 0 && offset == 0 {
+					fmt.Println(string(rsp.Body))
+				} else {
+					output.Print(map[string]any{"binaries": binaries})
+				}
 				return nil
 			}
 
-			if len(rsp.JSON200.Binaries) == 0 {
+			if len(binaries) == 0 {
 				fmt.Printf("you have no binaries\n")
 				return nil
 			}
 
-			table := make([][]string, len(rsp.JSON200.Binaries)+1)
+			table := make([][]string, len(binaries)+1)
 			table[0] = []string{"ID", "Status", "Unreferenced since"}
-			for i, bin := range rsp.JSON200.Binaries {
+			if wide {
+				table[0] = append(table[0], "API type", "Checksum")
+			}
+			for i, bin := range binaries {
 				table[i+1] = []string{
 					strconv.FormatInt(bin.Id, 10),
 					binStatusToString(bin.Status),
 					unrefString(bin.UnrefSince),
 				}
+				if wide {
+					table[i+1] = append(table[i+1], bin.ApiType, unrefString(bin.Checksum))
+				}
 			}
 			output.Table(table, output.Format(cmd))
 
+			if output.Format(cmd) == output.FmtHuman && total > len(binaries) {
+				fmt.Printf("Shown binaries %d-%d of %d\n", offset+1, offset+len(binaries), total)
+			}
 			return nil
 		},
 	}
+	cmdList.Flags().String("status", "", `Show binaries with the status ("pending", "compiled" or "failed")`)
+	cmdList.Flags().String("sort-by", "", "Sort by field: id, status or unref_since, '-' prefix means descending order")
+	cmdList.Flags().Int("limit", 0, "Maximum number of binaries to show")
+	cmdList.Flags().Int("offset", 0, "Number of binaries to skip")
+	cmdList.Flags().Bool("wide", false, "Show more binary properties")
+	cmdList.RegisterFlagCompletionFunc("status", cobra.FixedCompletions(
+		[]string{"pending", "compiled", "failed"},
+		cobra.ShellCompDirectiveNoFileComp,
+	))
+	cmdList.RegisterFlagCompletionFunc("sort-by", cobra.FixedCompletions(
+		[]string{"id", "-id", "status", "-status", "unref_since", "-unref_since"},
+		cobra.ShellCompDirectiveNoFileComp,
+	))
 
 	var cmdUpload = &cobra.Command{
 		Use:     "add",
 		Aliases: []string{"upload"},
 		Short:   "Add new binary",
 		Long: `Upload compiled Wasm binary. Specify binary filename with "--file" flag.
-If this flag is omitted, file contant is read from stdin.`,
+If this flag is omitted, file contant is read from stdin.
+With "--wait" flag, command waits until the binary is compiled and reports
+compilation errors, if any.`,
 		Args: cobra.NoArgs,
 		RunE: func(cmd *cobra.Command, args []string) error {
 			src, err := cmd.Flags().GetString("file")
@@ -82,6 +153,13 @@ If this flag is omitted, file contant is read from stdin.`,
 				return err
 			}
 
+			err = waitFor(cmd, func(ctx context.Context) error {
+				return waitForBinary(ctx, id)
+			})
+			if err != nil {
+				return err
+			}
+
 			fmt.Printf("Uploaded binary with ID %d\n", id)
 
 			return nil
@@ -90,10 +168,11 @@ If this flag is omitted, file contant is read from stdin.`,
 	cmdUpload.Flags().String("file", sourceStdin, "Wasm binary filename ('-' means stdin)")
 
 	var cmdGet = &cobra.Command{
-		Use:     "show <binary_id>",
-		Aliases: []string{"get"},
-		Short:   "Show binary details",
-		Args:    cobra.ExactArgs(1),
+		Use:               "show <binary_id>",
+		Aliases:           []string{"get"},
+		Short:             "Show binary details",
+		Args:              cobra.ExactArgs(1),
+		ValidArgsFunction: completeArgs(completeBinaries),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			id, err := strconv.ParseInt(args[0], 10, 64)
 			if err != nil {
@@ -127,11 +206,12 @@ If this flag is omitted, file contant is read from stdin.`,
 	}
 
 	var cmdDelete = &cobra.Command{
-		Use:     "delete <binary_id>",
-		Aliases: []string{"rm"},
-		Short:   "Delete the binary",
-		Long:    `Delete the binary. Binary cannot be deleted if it is still referenced by any app.`,
-		Args:    cobra.ExactArgs(1),
+		Use:               "delete <binary_id>",
+		Aliases:           []string{"rm"},
+		Short:             "Delete the binary",
+		Long:              `Delete the binary. Binary cannot be deleted if it is still referenced by any app.`,
+		Args:              cobra.ExactArgs(1),
+		ValidArgsFunction: completeArgs(completeBinaries),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			id, err := strconv.ParseInt(args[0], 10, 64)
 			if err != nil {
@@ -162,14 +242,26 @@ If this flag is omitted, file contant is read from stdin.`,
 }
 
 func uploadBinary(src string) (int64, error) {
-	r := os.Stdin
+	f := os.Stdin
 	var err error
 	if src != sourceStdin {
-		r, err = os.Open(src)
+		f, err = os.Open(src)
 		if err != nil {
 			return 0, fmt.Errorf("cannot open %s: %w", src, err)
 		}
-		defer r.Close()
+		defer f.Close()
+	}
+
+	var r io.Reader = f
+	var bar *progress.Reader
+	if out := progress.Out(); out != nil {
+		// size is unknown when stdin is a pipe, progress shows sent bytes only
+		var size int64
+		if fi, err := f.Stat(); err == nil && fi.Mode().IsRegular() {
+			size = fi.Size()
+		}
+		bar = progress.NewReader(f, size, out)
+		r = bar
 	}
 
 	rsp, err := client.StoreBinaryWithBodyWithResponse(
@@ -177,6 +269,9 @@ func uploadBinary(src string) (int64, error) {
 		wasmContentType,
 		r,
 	)
+	if bar != nil {
+		bar.Finish()
+	}
 	if err != nil {
 		return 0, fmt.Errorf("cannot upload the binary: %w", err)
 	}
@@ -187,6 +282,62 @@ func uploadBinary(src string) (int64, error) {
 	return rsp.JSON200.Id, nil
 }
 
+// filterBinaries returns binaries with the status, sorted by the field
+func filterBinaries(binaries []sdk.BinaryShort, status string, sortBy string) ([]sdk.BinaryShort, error) {
+	binaries = slices.Clone(binaries)
+	if status != "" {
+		match, err := binStatusMatcher(status)
+		if err != nil {
+			return nil, err
+		}
+		binaries = slices.DeleteFunc(binaries, func(b sdk.BinaryShort) bool {
+			return !match(b.Status)
+		})
+	}
+
+	if sortBy == "" {
+		return binaries, nil
+	}
+	field, desc := strings.CutPrefix(sortBy, "-")
+	var compare func(a, b sdk.BinaryShort) int
+	switch field {
+	case "id":
+		compare = func(a, b sdk.BinaryShort) int { return cmp.Compare(a.Id, b.Id) }
+	case "status":
+		compare = func(a, b sdk.BinaryShort) int { return cmp.Compare(a.Status, b.Status) }
+	case "unref_since":
+		compare = func(a, b sdk.BinaryShort) int {
+			return strings.Compare(unrefString(a.UnrefSince), unrefString(b.UnrefSince))
+		}
+	default:
+		return nil, fmt.Errorf(`cannot sort binaries by "%s"`, field)
+	}
+	slices.SortStableFunc(binaries, func(a, b sdk.BinaryShort) int {
+		if desc {
+			return compare(b, a)
+		}
+		return compare(a, b)
+	})
+	return binaries, nil
+}
+
+// binStatusMatcher returns binary status matching function for the status
+// code or name, "failed" matches any failed compilation
+func binStatusMatcher(s string) (func(int) bool, error) {
+	if code, err := strconv.Atoi(s); err == nil {
+		return func(status int) bool { return status == code }, nil
+	}
+	switch s {
+	case "pending":
+		return func(status int) bool { return status == binStatusPending }, nil
+	case "compiled":
+		return func(status int) bool { return status == binStatusOK }, nil
+	case "failed":
+		return func(status int) bool { return status > binStatusOK }, nil
+	}
+	return nil, fmt.Errorf(`unknown binary status "%s"`, s)
+}
+
 func binStatusToString(s int) string {
 	switch s {
 	case 0:
@@ -0,0 +1,47 @@
+package fastedge
+
+import (
+	"testing"
+
+	sdk "github.com/G-Core/FastEdge-client-sdk-go"
+	"github.com/alecthomas/assert"
+)
+
+func TestFilterBinaries(t *testing.T) {
+	binaries := []sdk.BinaryShort{
+		{Id: 3, Status: binStatusOK},
+		{Id: 1, Status: binStatusPending},
+		{Id: 4, Status: 2},
+		{Id: 2, Status: binStatusOK},
+	}
+
+	type TestCase struct {
+		Status   string
+		SortBy   string
+		Expected []int64
+	}
+
+	run := func(tc *TestCase) func(*testing.T) {
+		return func(t *testing.T) {
+			res, err := filterBinaries(binaries, tc.Status, tc.SortBy)
+			assert.NoError(t, err)
+			ids := []int64{}
+			for _, b := range res {
+				ids = append(ids, b.Id)
+			}
+			assert.Equal(t, tc.Expected, ids)
+		}
+	}
+
+	t.Run("no filter", run(&TestCase{Expected: []int64{3, 1, 4, 2}}))
+	t.Run("compiled", run(&TestCase{Status: "compiled", Expected: []int64{3, 2}}))
+	t.Run("failed", run(&TestCase{Status: "failed", Expected: []int64{4}}))
+	t.Run("status code", run(&TestCase{Status: "0", Expected: []int64{1}}))
+	t.Run("sort by id", run(&TestCase{SortBy: "id", Expected: []int64{1, 2, 3, 4}}))
+	t.Run("sort by status desc", run(&TestCase{SortBy: "-status", Expected: []int64{4, 3, 2, 1}}))
+
+	_, err := filterBinaries(binaries, "", "name")
+	assert.EqualError(t, err, `cannot sort binaries by "name"`)
+	_, err = filterBinaries(binaries, "broken", "")
+	assert.EqualError(t, err, `unknown binary status "broken"`)
+}
@@ -0,0 +1,257 @@
+package fastedge
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+
+	sdk "github.com/G-Core/FastEdge-client-sdk-go"
+	"github.com/spf13/cobra"
+
+	e "github.com/G-core/gcore-cli/internal/errors"
+	"github.com/G-core/gcore-cli/internal/output"
+	"github.com/G-core/gcore-cli/internal/sure"
+)
+
+// number of apps, requested at once when listing all apps
+const appsPageSize = 100
+
+// bulkResult is the outcome of the operation on one app
+type bulkResult struct {
+	Id    int64  `json:"id"`
+	Name  string `json:"name"`
+	Error string `json:"error,omitempty"`
+}
+
+// bulkAction describes the operation, applied to the set of apps
+type bulkAction struct {
+	verb    string // used in confirmation, like "delete"
+	done    string // used in result message, like "deleted"
+	confirm bool   // confirm even for the single app
+	run     func(id int64) ([]byte, error)
+}
+
+func bulkFlags(cmd *cobra.Command) {
+	cmd.Flags().Bool("all", false, "Select all apps")
+	cmd.Flags().String("selector", "", `Select apps by name, "name=<name>" or "name~=<regex>"`)
+	cmd.Flags().String("status", "", `Select apps by status ("enabled", "disabled" etc.)`)
+	cmd.Flags().Int("parallel", 4, "Maximum number of apps processed at once")
+	cmd.RegisterFlagCompletionFunc("status", cobra.FixedCompletions(
+		[]string{appStatusToString(appStatusDraft), appStatusToString(appStatusEnabled), appStatusToString(appStatusOff)},
+		cobra.ShellCompDirectiveNoFileComp,
+	))
+}
+
+// selectApps returns apps, specified by names in args and selection flags
+func selectApps(cmd *cobra.Command, args []string) ([]bulkResult, error) {
+	all, err := cmd.Flags().GetBool("all")
+	if err != nil {
+		return nil, err
+	}
+	selector, err := cmd.Flags().GetString("selector")
+	if err != nil {
+		return nil, err
+	}
+	status, err := cmd.Flags().GetString("status")
+	if err != nil {
+		return nil, err
+	}
+	if len(args) == 0 && !all && selector == "" && status == "" {
+		return nil, errors.New(`specify app names or select apps using "--all", "--selector" or "--status" flags`)
+	}
+
+	var apps []bulkResult
+	for _, name := range args {
+		id, err := getAppIdByName(name)
+		if err != nil {
+			return nil, fmt.Errorf("cannot find app by name: %w", err)
+		}
+		if slices.ContainsFunc(apps, func(r bulkResult) bool { return r.Id == id }) {
+			continue
+		}
+		apps = append(apps, bulkResult{Id: id, Name: name})
+	}
+
+	if all || selector != "" || status != "" {
+		match, err := parseSelector(selector)
+		if err != nil {
+			return nil, err
+		}
+		var params sdk.ListAppsParams
+		if status != "" {
+			code, err := parseAppStatus(status)
+			if err != nil {
+				return nil, err
+			}
+			params.Status = &code
+		}
+
+		list, err := listAllApps(params)
+		if err != nil {
+			return nil, err
+		}
+		for _, app := range list {
+			if !match(app.Name) {
+				continue
+			}
+			if slices.ContainsFunc(apps, func(r bulkResult) bool { return r.Id == app.Id }) {
+				continue
+			}
+			apps = append(apps, bulkResult{Id: app.Id, Name: app.Name})
+		}
+	}
+
+	if len(apps) == 0 {
+		return nil, errors.New("no apps match the selection")
+	}
+	return apps, nil
+}
+
+// listAllApps returns apps, matching params, reading all pages of the list
+func listAllApps(params sdk.ListAppsParams) ([]sdk.AppShort, error) {
+	var apps []sdk.AppShort
+	params.Limit = newPointer(appsPageSize)
+	for {
+		params.Offset = newPointer(len(apps))
+		rsp, err := client.ListAppsWithResponse(context.Background(), &params)
+		if err != nil {
+			return nil, fmt.Errorf("getting the list of apps: %w", err)
+		}
+		if rsp.StatusCode() != http.StatusOK || rsp.JSON200 == nil {
+			return nil, fmt.Errorf("getting the list of apps: %s", extractErrorMessage(rsp.Body))
+		}
+		apps = append(apps, rsp.JSON200.Apps...)
+
+		page := len(rsp.JSON200.Apps)
+		if page < appsPageSize || (rsp.JSON200.Count != nil && len(apps) >= *rsp.JSON200.Count) {
+			return apps, nil
+		}
+	}
+}
+
+// parseSelector returns app name matching function for "name=<name>" or
+// "name~=<regex>" selector, empty selector matches any name
+func parseSelector(selector string) (func(name string) bool, error) {
+	if selector == "" {
+		return func(string) bool { return true }, nil
+	}
+	if re, ok := strings.CutPrefix(selector, "name~="); ok {
+		rx, err := regexp.Compile(re)
+		if err != nil {
+			return nil, fmt.Errorf("invalid selector regex: %w", err)
+		}
+		return rx.MatchString, nil
+	}
+	if name, ok := strings.CutPrefix(selector, "name="); ok {
+		return func(s string) bool { return s == name }, nil
+	}
+	return nil, fmt.Errorf(`invalid selector "%s", expected "name=<name>" or "name~=<regex>"`, selector)
+}
+
+func parseAppStatus(s string) (int, error) {
+	if code, err := strconv.Atoi(s); err == nil {
+		return code, nil
+	}
+	for code := appStatusDraft; code <= appStatusOff; code++ {
+		if appStatusToString(code) == s {
+			return code, nil
+		}
+	}
+	return 0, fmt.Errorf(`unknown app status "%s"`, s)
+}
+
+// runBulk applies the action to selected apps with bounded parallelism.
+// Operation on the single app, specified by name, is reported as before,
+// otherwise the result table is shown.
+func runBulk(cmd *cobra.Command, args []string, action bulkAction) error {
+	apps, err := selectApps(cmd, args)
+	if err != nil {
+		return err
+	}
+	single := len(apps) == 1 && len(args) == 1
+
+	if single {
+		if action.confirm && !sure.AreYou(cmd, fmt.Sprintf("%s app %d", action.verb, apps[0].Id)) {
+			return e.ErrAborted
+		}
+		body, err := action.run(apps[0].Id)
+		if err != nil {
+			return err
+		}
+		if output.Format(cmd) == output.FmtJSON {
+			fmt.Println(string(body))
+			return nil
+		}
+		fmt.Printf("App %d %s\n", apps[0].Id, action.done)
+		return nil
+	}
+
+	names := make([]string, len(apps))
+	for i, app := range apps {
+		names[i] = app.Name
+	}
+	if !sure.AreYou(cmd, fmt.Sprintf("%s %d apps (%s)", action.verb, len(apps), strings.Join(names, ", "))) {
+		return e.ErrAborted
+	}
+
+	parallel, err := cmd.Flags().GetInt("parallel")
+	if err != nil {
+		return err
+	}
+	if parallel < 1 {
+		parallel = 1
+	}
+
+	sem := make(chan struct{}, parallel)
+	var wg sync.WaitGroup
+	for i := range apps {
+		wg.Add(1)
+		sem <- struct{}{}
+		go func(app *bulkResult) {
+			defer func() {
+				<-sem
+				wg.Done()
+			}()
+			if _, err := action.run(app.Id); err != nil {
+				app.Error = err.Error()
+			}
+		}(&apps[i])
+	}
+	wg.Wait()
+
+	failed := 0
+	for _, app := range apps {
+		if app.Error != "" {
+			failed++
+		}
+	}
+
+	if output.Format(cmd) == output.FmtJSON {
+		output.Print(apps)
+	} else {
+		table := make([][]string, len(apps)+1)
+		table[0] = []string{"ID", "Name", "Result"}
+		for i, app := range apps {
+			result := action.done
+			if app.Error != "" {
+				result = "failed: " + app.Error
+			}
+			table[i+1] = []string{strconv.FormatInt(app.Id, 10), app.Name, result}
+		}
+		output.Table(table, output.Format(cmd))
+	}
+
+	if failed > 0 {
+		return &e.CliError{
+			Err:  fmt.Errorf("%d of %d apps failed", failed, len(apps)),
+			Code: 1,
+		}
+	}
+	return nil
+}
@@ -0,0 +1,212 @@
+package fastedge
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+
+	sdk "github.com/G-Core/FastEdge-client-sdk-go"
+	"github.com/alecthomas/assert"
+	"github.com/spf13/cobra"
+)
+
+func TestParseSelector(t *testing.T) {
+	type TestCase struct {
+		Selector string
+		Match    []string
+		NoMatch  []string
+		Err      string
+	}
+
+	run := func(tc *TestCase) func(*testing.T) {
+		return func(t *testing.T) {
+			match, err := parseSelector(tc.Selector)
+			if tc.Err != "" {
+				assert.EqualError(t, err, tc.Err)
+				return
+			}
+			assert.NoError(t, err)
+			for _, name := range tc.Match {
+				assert.True(t, match(name), name)
+			}
+			for _, name := range tc.NoMatch {
+				assert.False(t, match(name), name)
+			}
+		}
+	}
+
+	t.Run("empty", run(&TestCase{
+		Selector: "",
+		Match:    []string{"foo", ""},
+	}))
+	t.Run("exact name", run(&TestCase{
+		Selector: "name=foo",
+		Match:    []string{"foo"},
+		NoMatch:  []string{"foo-canary", "Foo"},
+	}))
+	t.Run("regex", run(&TestCase{
+		Selector: "name~=^foo-",
+		Match:    []string{"foo-canary", "foo-"},
+		NoMatch:  []string{"foo", "bar-foo-"},
+	}))
+	t.Run("invalid regex", run(&TestCase{
+		Selector: "name~=(",
+		Err:      "invalid selector regex: error parsing regexp: missing closing ): `(`",
+	}))
+	t.Run("unknown", run(&TestCase{
+		Selector: "status=1",
+		Err:      `invalid selector "status=1", expected "name=<name>" or "name~=<regex>"`,
+	}))
+}
+
+func TestParseAppStatus(t *testing.T) {
+	type TestCase struct {
+		Status   string
+		Expected int
+		Err      string
+	}
+
+	run := func(tc *TestCase) func(*testing.T) {
+		return func(t *testing.T) {
+			code, err := parseAppStatus(tc.Status)
+			if tc.Err != "" {
+				assert.EqualError(t, err, tc.Err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tc.Expected, code)
+		}
+	}
+
+	t.Run("numeric", run(&TestCase{
+		Status:   "2",
+		Expected: appStatusOff,
+	}))
+	t.Run("draft", run(&TestCase{
+		Status:   appStatusToString(appStatusDraft),
+		Expected: appStatusDraft,
+	}))
+	t.Run("enabled", run(&TestCase{
+		Status:   appStatusToString(appStatusEnabled),
+		Expected: appStatusEnabled,
+	}))
+	t.Run("disabled", run(&TestCase{
+		Status:   appStatusToString(appStatusOff),
+		Expected: appStatusOff,
+	}))
+	t.Run("unknown", run(&TestCase{
+		Status: "sleeping",
+		Err:    `unknown app status "sleeping"`,
+	}))
+}
+
+func TestSelectApps(t *testing.T) {
+	// more apps than fit into one page
+	all := make([]sdk.AppShort, appsPageSize+20)
+	for i := range all {
+		all[i] = sdk.AppShort{Id: int64(i + 1), Name: "app" + strconv.Itoa(i+1)}
+	}
+	all[0].Name = "foo"
+	all[1].Name = "foo-canary"
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if id, ok := strings.CutPrefix(r.URL.Path, "/v1/apps/"); ok {
+			n, _ := strconv.Atoi(id)
+			if n < 1 || n > len(all) {
+				w.WriteHeader(http.StatusNotFound)
+				return
+			}
+			json.NewEncoder(w).Encode(all[n-1])
+			return
+		}
+		query := r.URL.Query()
+		var apps []sdk.AppShort
+		if name := query.Get("name"); name != "" {
+			for _, app := range all {
+				if app.Name == name {
+					apps = append(apps, app)
+				}
+			}
+		} else {
+			offset, _ := strconv.Atoi(query.Get("offset"))
+			limit, _ := strconv.Atoi(query.Get("limit"))
+			apps = all[min(offset, len(all)):min(offset+limit, len(all))]
+		}
+		json.NewEncoder(w).Encode(map[string]any{"apps": apps, "count": len(all)})
+	}))
+	defer srv.Close()
+
+	var err error
+	client, err = sdk.NewClientWithResponses(srv.URL)
+	assert.NoError(t, err)
+
+	type TestCase struct {
+		Args     []string
+		Flags    []string
+		Expected []int64
+		Err      string
+	}
+
+	run := func(tc *TestCase) func(*testing.T) {
+		return func(t *testing.T) {
+			cmd := &cobra.Command{}
+			bulkFlags(cmd)
+			assert.NoError(t, cmd.ParseFlags(tc.Flags))
+
+			apps, err := selectApps(cmd, tc.Args)
+			if tc.Err != "" {
+				assert.EqualError(t, err, tc.Err)
+				return
+			}
+			assert.NoError(t, err)
+			ids := make([]int64, len(apps))
+			for i, app := range apps {
+				ids[i] = app.Id
+			}
+			assert.Equal(t, tc.Expected, ids)
+		}
+	}
+
+	t.Run("nothing selected", run(&TestCase{
+		Err: `specify app names or select apps using "--all", "--selector" or "--status" flags`,
+	}))
+	t.Run("names", run(&TestCase{
+		Args:     []string{"foo-canary", "foo"},
+		Expected: []int64{2, 1},
+	}))
+	t.Run("all pages", run(&TestCase{
+		Flags: []string{"--all"},
+		Expected: func() []int64 {
+			ids := make([]int64, len(all))
+			for i := range ids {
+				ids[i] = int64(i + 1)
+			}
+			return ids
+		}(),
+	}))
+	t.Run("selector on the last page", run(&TestCase{
+		Flags:    []string{"--selector", "name~=^app11[0-9]$"},
+		Expected: []int64{110, 111, 112, 113, 114, 115, 116, 117, 118, 119},
+	}))
+	t.Run("duplicate names", run(&TestCase{
+		Args:     []string{"foo", "foo-canary", "foo"},
+		Expected: []int64{1, 2},
+	}))
+	t.Run("name and ID of the same app", run(&TestCase{
+		Args:     []string{"foo", "1"},
+		Expected: []int64{1},
+	}))
+	t.Run("names and selector without duplicates", run(&TestCase{
+		Args:     []string{"foo"},
+		Flags:    []string{"--selector", "name~=^foo"},
+		Expected: []int64{1, 2},
+	}))
+	t.Run("no match", run(&TestCase{
+		Flags: []string{"--selector", "name=bar"},
+		Err:   "no apps match the selection",
+	}))
+}
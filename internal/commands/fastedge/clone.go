@@ -0,0 +1,125 @@
+package fastedge
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"net/http"
+
+	sdk "github.com/G-Core/FastEdge-client-sdk-go"
+	"github.com/spf13/cobra"
+
+	e "github.com/G-core/gcore-cli/internal/errors"
+	"github.com/G-core/gcore-cli/internal/output"
+	"github.com/G-core/gcore-cli/internal/sure"
+)
+
+func appClone() *cobra.Command {
+	var cmdClone = &cobra.Command{
+		Use:   "clone <src_app_name> <new_app_name>",
+		Short: "Create a copy of the app",
+		Long: `Create new app with the same binary, environment and response headers as
+the source app. Environment variables can be overridden using "--env" flag,
+empty value removes the variable from the copy.`,
+		Args:              cobra.ExactArgs(2),
+		ValidArgsFunction: completeArgs(completeApps),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			id, err := getAppIdByName(args[0])
+			if err != nil {
+				return fmt.Errorf("cannot find app by name: %w", err)
+			}
+
+			src, err := getApp(id)
+			if err != nil {
+				return err
+			}
+
+			overrides, err := getMapParamP("env", cmd.Flags().GetStringArray)
+			if err != nil {
+				return err
+			}
+			env := maps.Clone(derefMap(src.Env))
+			if env == nil {
+				env = make(map[string]string)
+			}
+			for k, v := range overrides {
+				if v == "" {
+					delete(env, k)
+				} else {
+					env[k] = v
+				}
+			}
+
+			app := sdk.App{
+				Name:       &args[1],
+				Binary:     src.Binary,
+				Comment:    src.Comment,
+				Env:        &env,
+				RspHeaders: src.RspHeaders,
+				Status:     src.Status,
+			}
+			rsp, err := client.AddAppWithResponse(context.Background(), app)
+			if err != nil {
+				return fmt.Errorf("adding the app: %w", err)
+			}
+			if rsp.StatusCode() != http.StatusOK {
+				return fmt.Errorf("adding the app: %s", extractErrorMessage(rsp.Body))
+			}
+			recordDeployment(rsp.JSON200.Id)
+
+			if output.Format(cmd) == output.FmtJSON {
+				fmt.Println(string(rsp.Body))
+				return nil
+			}
+
+			fmt.Printf(
+				"ID:\t%d\nName:\t%s\nStatus:\t%s\nUrl:\t%s\n",
+				rsp.JSON200.Id,
+				rsp.JSON200.Name,
+				appStatusToString(rsp.JSON200.Status),
+				unrefString(rsp.JSON200.Url),
+			)
+			return nil
+		},
+	}
+	cmdClone.Flags().StringArray("env", nil, "Environment overrides, in name=value format")
+
+	return cmdClone
+}
+
+func appRename() *cobra.Command {
+	return &cobra.Command{
+		Use:   "rename <app_name> <new_app_name>",
+		Short: "Rename the app",
+		Long: `Change the app name. Please note that app URL is derived from its name,
+so it changes as well.`,
+		Args:              cobra.ExactArgs(2),
+		ValidArgsFunction: completeArgs(completeApps),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			id, err := getAppIdByName(args[0])
+			if err != nil {
+				return fmt.Errorf("cannot find app by name: %w", err)
+			}
+
+			if !sure.AreYou(cmd, fmt.Sprintf("rename app %d to %q", id, args[1])) {
+				return e.ErrAborted
+			}
+
+			rsp, err := patchApp(id, sdk.App{Name: &args[1]})
+			if err != nil {
+				return fmt.Errorf("renaming the app: %w", err)
+			}
+			if rsp.StatusCode() != http.StatusOK {
+				return fmt.Errorf("renaming the app: %s", extractErrorMessage(rsp.Body))
+			}
+
+			if output.Format(cmd) == output.FmtJSON {
+				fmt.Println(string(rsp.Body))
+				return nil
+			}
+
+			fmt.Printf("App %d renamed to %s\nUrl:\t%s\n", id, rsp.JSON200.Name, unrefString(rsp.JSON200.Url))
+			return nil
+		},
+	}
+}
@@ -0,0 +1,158 @@
+package fastedge
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"time"
+
+	sdk "github.com/G-Core/FastEdge-client-sdk-go"
+	"github.com/dromara/carbon/v2"
+	"github.com/spf13/cobra"
+
+	"github.com/G-core/gcore-cli/internal/config"
+)
+
+// completion results are cached for a short time, so repeated Tab presses
+// don't query API every time
+const completionTTL = 30 * time.Second
+
+// apiIdentity returns string, identifying API URL and credentials, so
+// locally cached data of different accounts doesn't mix
+var apiIdentity func() string
+
+// completeArgs returns completion function for positional arguments, each
+// completed by the function at the same position; nil function or extra
+// argument gets no completion
+func completeArgs(fns ...cobra.CompletionFunc) cobra.CompletionFunc {
+	return func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		if len(args) >= len(fns) || fns[len(args)] == nil {
+			return nil, cobra.ShellCompDirectiveNoFileComp
+		}
+		return fns[len(args)](cmd, args, toComplete)
+	}
+}
+
+// completeFiles falls back to shell file name completion
+func completeFiles(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	return nil, cobra.ShellCompDirectiveDefault
+}
+
+func completeApps(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	names, err := cachedCompletion(cmd, "apps", func() ([]string, error) {
+		rsp, err := client.ListAppsWithResponse(context.Background(), &sdk.ListAppsParams{})
+		if err != nil {
+			return nil, err
+		}
+		if rsp.StatusCode() != http.StatusOK || rsp.JSON200 == nil {
+			return nil, errors.New(extractErrorMessage(rsp.Body))
+		}
+		names := make([]string, len(rsp.JSON200.Apps))
+		for i, app := range rsp.JSON200.Apps {
+			names[i] = app.Name + "\t" + appStatusToString(app.Status)
+		}
+		return names, nil
+	})
+	if err != nil {
+		return nil, cobra.ShellCompDirectiveError
+	}
+	return names, cobra.ShellCompDirectiveNoFileComp
+}
+
+func completeBinaries(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	ids, err := cachedCompletion(cmd, "binaries", func() ([]string, error) {
+		rsp, err := client.ListBinariesWithResponse(context.Background())
+		if err != nil {
+			return nil, err
+		}
+		if rsp.StatusCode() != http.StatusOK || rsp.JSON200 == nil {
+			return nil, errors.New(extractErrorMessage(rsp.Body))
+		}
+		ids := make([]string, len(rsp.JSON200.Binaries))
+		for i, bin := range rsp.JSON200.Binaries {
+			ids[i] = strconv.FormatInt(bin.Id, 10) + "\t" + binStatusToString(bin.Status)
+		}
+		return ids, nil
+	})
+	if err != nil {
+		return nil, cobra.ShellCompDirectiveError
+	}
+	return ids, cobra.ShellCompDirectiveNoFileComp
+}
+
+// completeEdges completes edge names, found in today's logs of the app,
+// specified by the first argument
+func completeEdges(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	if len(args) == 0 {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+	edges, err := cachedCompletion(cmd, "edges-"+args[0], func() ([]string, error) {
+		id, err := getAppIdByName(args[0])
+		if err != nil {
+			return nil, err
+		}
+		from := carbon.Now(carbon.UTC).StartOfDay().StdTime()
+		rsp, err := client.ListLogsWithResponse(context.Background(), id, &sdk.ListLogsParams{From: &from})
+		if err != nil {
+			return nil, err
+		}
+		if rsp.StatusCode() != http.StatusOK || rsp.JSON200 == nil {
+			return nil, errors.New(extractErrorMessage(rsp.Body))
+		}
+		var edges []string
+		if rsp.JSON200.Logs != nil {
+			for _, log := range *rsp.JSON200.Logs {
+				if log.Edge != nil && !slices.Contains(edges, *log.Edge) {
+					edges = append(edges, *log.Edge)
+				}
+			}
+		}
+		return edges, nil
+	})
+	if err != nil {
+		return nil, cobra.ShellCompDirectiveError
+	}
+	return edges, cobra.ShellCompDirectiveNoFileComp
+}
+
+// cachedCompletion returns completion values from local cache, refreshing
+// it with fetch function when the cache is missing or expired
+func cachedCompletion(cmd *cobra.Command, kind string, fetch func() ([]string, error)) ([]string, error) {
+	path := ""
+	if dir, err := config.Dir("cache", "completion"); err == nil {
+		sum := sha256.Sum256([]byte(apiIdentity() + "\n" + kind))
+		path = filepath.Join(dir, hex.EncodeToString(sum[:16])+".json")
+		if fi, err := os.Stat(path); err == nil && time.Since(fi.ModTime()) < completionTTL {
+			var values []string
+			if buf, err := os.ReadFile(path); err == nil && json.Unmarshal(buf, &values) == nil {
+				return values, nil
+			}
+		}
+	}
+
+	// completion skips command hooks, so the client is not initialized
+	if client == nil {
+		if err := connect(cmd); err != nil {
+			return nil, err
+		}
+	}
+	values, err := fetch()
+	if err != nil {
+		return nil, fmt.Errorf("fetching %s: %w", kind, err)
+	}
+
+	if path != "" {
+		if buf, err := json.Marshal(values); err == nil {
+			os.WriteFile(path, buf, 0600)
+		}
+	}
+	return values, nil
+}
@@ -0,0 +1,31 @@
+package fastedge
+
+import (
+	"testing"
+
+	"github.com/alecthomas/assert"
+	"github.com/spf13/cobra"
+)
+
+func TestCompleteArgs(t *testing.T) {
+	fixed := func(value string) cobra.CompletionFunc {
+		return func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+			return []string{value}, cobra.ShellCompDirectiveNoFileComp
+		}
+	}
+	complete := completeArgs(fixed("app"), nil, completeFiles)
+
+	values, directive := complete(nil, nil, "")
+	assert.Equal(t, []string{"app"}, values)
+	assert.Equal(t, cobra.ShellCompDirectiveNoFileComp, directive)
+
+	values, directive = complete(nil, []string{"a"}, "")
+	assert.Equal(t, 0, len(values))
+	assert.Equal(t, cobra.ShellCompDirectiveNoFileComp, directive)
+
+	_, directive = complete(nil, []string{"a", "b"}, "")
+	assert.Equal(t, cobra.ShellCompDirectiveDefault, directive)
+
+	_, directive = complete(nil, []string{"a", "b", "c"}, "")
+	assert.Equal(t, cobra.ShellCompDirectiveNoFileComp, directive)
+}
@@ -0,0 +1,279 @@
+package fastedge
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptrace"
+	"os"
+	"os/exec"
+	"runtime"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	e "github.com/G-core/gcore-cli/internal/errors"
+	"github.com/G-core/gcore-cli/internal/output"
+)
+
+// curlResult is the request outcome, shown in JSON output mode
+type curlResult struct {
+	Method    string              `json:"method"`
+	Url       string              `json:"url"`
+	Status    int                 `json:"status"`
+	TimeToRsp int64               `json:"time_to_response_ms"`
+	TimeTotal int64               `json:"time_total_ms"`
+	Headers   map[string][]string `json:"headers"`
+	Body      string              `json:"body,omitempty"`
+}
+
+func appCurl() *cobra.Command {
+	var cmdCurl = &cobra.Command{
+		Use:   "curl <app_name> [path]",
+		Short: "Send HTTP request to the app",
+		Long: `Send HTTP request to the app URL and show response status, timing,
+headers and body. Request body can be specified with "-d" flag, "@filename"
+reads it from the file and "@-" from stdin. Redirects are not followed,
+like in curl without "-L". Command fails if the app responds with error
+status (4xx or 5xx), so it can be used as a smoke test after deployment.`,
+		Args:              cobra.RangeArgs(1, 2),
+		ValidArgsFunction: completeArgs(completeApps),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			id, err := getAppIdByName(args[0])
+			if err != nil {
+				return fmt.Errorf("cannot find app by name: %w", err)
+			}
+			url, err := appUrl(id)
+			if err != nil {
+				return err
+			}
+			if len(args) > 1 {
+				url = strings.TrimSuffix(url, "/") + "/" + strings.TrimPrefix(args[1], "/")
+			}
+
+			req, err := curlRequest(cmd, url)
+			if err != nil {
+				return err
+			}
+			timeout, err := cmd.Flags().GetDuration("timeout")
+			if err != nil {
+				return err
+			}
+			noBody, err := cmd.Flags().GetBool("no-body")
+			if err != nil {
+				return err
+			}
+
+			res, err := doCurl(req, timeout)
+			if err != nil {
+				return err
+			}
+			if noBody {
+				res.Body = ""
+			}
+
+			if output.Format(cmd) == output.FmtJSON {
+				output.Print(res)
+			} else {
+				fmt.Printf("%s %s\n", res.Method, res.Url)
+				fmt.Printf("Status: %d %s\n", res.Status, http.StatusText(res.Status))
+				fmt.Printf("Time: %dms (response started after %dms)\n", res.TimeTotal, res.TimeToRsp)
+				names := make([]string, 0, len(res.Headers))
+				for name := range res.Headers {
+					names = append(names, name)
+				}
+				slices.Sort(names)
+				for _, name := range names {
+					for _, val := range res.Headers[name] {
+						fmt.Printf("%s: %s\n", name, val)
+					}
+				}
+				if res.Body != "" {
+					fmt.Printf("\n%s", res.Body)
+					if !strings.HasSuffix(res.Body, "\n") {
+						fmt.Println()
+					}
+				}
+			}
+
+			if res.Status >= http.StatusBadRequest {
+				return &e.CliError{
+					Err:  fmt.Errorf("app responded with status %d", res.Status),
+					Code: 1,
+				}
+			}
+			return nil
+		},
+	}
+	cmdCurl.Flags().StringP("request", "X", "", "HTTP method (default is GET, or POST if data is specified)")
+	cmdCurl.Flags().StringArrayP("header", "H", nil, "Request header, in 'Name: value' format")
+	cmdCurl.Flags().StringP("data", "d", "", "Request body, '@filename' reads it from the file, '@-' from stdin")
+	cmdCurl.Flags().Duration("timeout", 30*time.Second, "Request timeout")
+	cmdCurl.Flags().Bool("no-body", false, "Don't show response body")
+	cmdCurl.RegisterFlagCompletionFunc("request", cobra.FixedCompletions(
+		[]string{http.MethodGet, http.MethodHead, http.MethodPost, http.MethodPut, http.MethodPatch, http.MethodDelete, http.MethodOptions},
+		cobra.ShellCompDirectiveNoFileComp,
+	))
+
+	return cmdCurl
+}
+
+func appOpen() *cobra.Command {
+	var cmdOpen = &cobra.Command{
+		Use:               "open <app_name>",
+		Short:             "Open the app URL in the browser",
+		Args:              cobra.ExactArgs(1),
+		ValidArgsFunction: completeArgs(completeApps),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			id, err := getAppIdByName(args[0])
+			if err != nil {
+				return fmt.Errorf("cannot find app by name: %w", err)
+			}
+			url, err := appUrl(id)
+			if err != nil {
+				return err
+			}
+
+			var browser *exec.Cmd
+			switch runtime.GOOS {
+			case "darwin":
+				browser = exec.Command("open", url)
+			case "windows":
+				browser = exec.Command("rundll32", "url.dll,FileProtocolHandler", url)
+			default:
+				browser = exec.Command("xdg-open", url)
+			}
+			if err := browser.Start(); err != nil {
+				return &e.CliError{
+					Err:  fmt.Errorf("cannot open the browser: %w", err),
+					Hint: "Open " + url + " manually",
+					Code: 1,
+				}
+			}
+			fmt.Println(url)
+			return nil
+		},
+	}
+
+	return cmdOpen
+}
+
+func appUrl(id int64) (string, error) {
+	app, err := getApp(id)
+	if err != nil {
+		return "", err
+	}
+	if app.Url == nil || *app.Url == "" {
+		return "", fmt.Errorf("app %d has no URL yet", id)
+	}
+	return *app.Url, nil
+}
+
+// curlRequest builds the request from command flags
+func curlRequest(cmd *cobra.Command, url string) (*http.Request, error) {
+	method, err := cmd.Flags().GetString("request")
+	if err != nil {
+		return nil, err
+	}
+	headers, err := cmd.Flags().GetStringArray("header")
+	if err != nil {
+		return nil, err
+	}
+	data, err := cmd.Flags().GetString("data")
+	if err != nil {
+		return nil, err
+	}
+
+	var body io.Reader
+	if cmd.Flags().Changed("data") {
+		if method == "" {
+			method = http.MethodPost
+		}
+		switch {
+		case data == "@-":
+			body = os.Stdin
+		case strings.HasPrefix(data, "@"):
+			buf, err := os.ReadFile(data[1:])
+			if err != nil {
+				return nil, fmt.Errorf("cannot read request body: %w", err)
+			}
+			body = bytes.NewReader(buf)
+		default:
+			body = strings.NewReader(data)
+		}
+	}
+	if method == "" {
+		method = http.MethodGet
+	}
+
+	req, err := http.NewRequest(strings.ToUpper(method), url, body)
+	if err != nil {
+		return nil, fmt.Errorf("creating the request: %w", err)
+	}
+	for _, h := range headers {
+		name, value, ok := strings.Cut(h, ":")
+		if !ok {
+			return nil, fmt.Errorf("invalid header '%s', expected 'Name: value'", h)
+		}
+		name, value = strings.TrimSpace(name), strings.TrimSpace(value)
+		// Go client sends req.Host, ignoring "Host" header
+		if strings.EqualFold(name, "Host") {
+			req.Host = value
+			continue
+		}
+		req.Header.Add(name, value)
+	}
+	if body != nil && req.Header.Get("Content-Type") == "" {
+		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	}
+	return req, nil
+}
+
+func doCurl(req *http.Request, timeout time.Duration) (*curlResult, error) {
+	start := time.Now()
+	var firstByte time.Time
+	trace := &httptrace.ClientTrace{
+		GotFirstResponseByte: func() { firstByte = time.Now() },
+	}
+	req = req.WithContext(httptrace.WithClientTrace(req.Context(), trace))
+
+	// redirects are not followed, so the actual response status is reported
+	httpClient := &http.Client{
+		Timeout: timeout,
+		CheckRedirect: func(*http.Request, []*http.Request) error {
+			return http.ErrUseLastResponse
+		},
+	}
+	rsp, err := httpClient.Do(req)
+	if err != nil {
+		var netErr net.Error
+		if errors.As(err, &netErr) && netErr.Timeout() {
+			return nil, fmt.Errorf("request to %s timed out", req.URL)
+		}
+		return nil, fmt.Errorf("sending the request: %w", err)
+	}
+	defer rsp.Body.Close()
+	body, err := io.ReadAll(rsp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("reading the response: %w", err)
+	}
+	total := time.Since(start)
+	if firstByte.IsZero() {
+		firstByte = time.Now()
+	}
+
+	return &curlResult{
+		Method:    req.Method,
+		Url:       req.URL.String(),
+		Status:    rsp.StatusCode,
+		TimeToRsp: firstByte.Sub(start).Milliseconds(),
+		TimeTotal: total.Milliseconds(),
+		Headers:   rsp.Header,
+		Body:      string(body),
+	}, nil
+}
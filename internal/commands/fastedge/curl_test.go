@@ -0,0 +1,51 @@
+package fastedge
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/alecthomas/assert"
+	"github.com/spf13/cobra"
+)
+
+func TestCurl(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		w.Header().Set("X-Method", r.Method)
+		w.Header().Set("X-Test", r.Header.Get("X-Test"))
+		w.Header().Set("X-Host", r.Host)
+		if r.URL.Path == "/old" {
+			http.Redirect(w, r, "/new", http.StatusMovedPermanently)
+			return
+		}
+		io.WriteString(w, r.URL.Path+" "+string(body))
+	}))
+	defer srv.Close()
+
+	cmd := &cobra.Command{}
+	cmd.Flags().StringP("request", "X", "", "")
+	cmd.Flags().StringArrayP("header", "H", nil, "")
+	cmd.Flags().StringP("data", "d", "", "")
+	assert.NoError(t, cmd.ParseFlags([]string{"-H", "X-Test: yes", "-H", "Host: example.com", "-d", "hello"}))
+
+	req, err := curlRequest(cmd, srv.URL+"/path")
+	assert.NoError(t, err)
+	res, err := doCurl(req, time.Second)
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, res.Status)
+	assert.Equal(t, "/path hello", res.Body)
+	assert.Equal(t, []string{"POST"}, res.Headers["X-Method"])
+	assert.Equal(t, []string{"yes"}, res.Headers["X-Test"])
+	assert.Equal(t, []string{"example.com"}, res.Headers["X-Host"])
+
+	// redirect is reported, not followed
+	req, err = curlRequest(cmd, srv.URL+"/old")
+	assert.NoError(t, err)
+	res, err = doCurl(req, time.Second)
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusMovedPermanently, res.Status)
+	assert.Equal(t, []string{"/new"}, res.Headers["Location"])
+}
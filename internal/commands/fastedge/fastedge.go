@@ -10,32 +10,56 @@ import (
 	"github.com/spf13/cobra"
 
 	sdk "github.com/G-Core/FastEdge-client-sdk-go"
+
+	"github.com/G-core/gcore-cli/internal/apiclient"
 )
 
-var client *sdk.ClientWithResponses
+var (
+	client *sdk.ClientWithResponses
+	// connect initializes the client, it is called before running any
+	// command, and also from shell completion functions, which skip
+	// command hooks
+	connect func(cmd *cobra.Command) error
+)
 
 // top-level FastEdge command
 func Commands(baseUrl string, authFunc func(ctx context.Context, req *http.Request) error) (*cobra.Command, error) {
 	var local bool
+	apiIdentity = func() string {
+		return apiclient.Identity(baseUrl, authFunc)
+	}
+	connect = func(cmd *cobra.Command) error {
+		var err error
+		url := baseUrl
+		if !local {
+			url += "/fastedge"
+		}
+		client, err = sdk.NewClientWithVersionCheck(
+			url,
+			"gcore-cli",
+			"Gcore CLI tool",
+			sdk.WithRequestEditorFn(authFunc),
+		)
+		if err != nil {
+			return fmt.Errorf("cannot init SDK: %w", err)
+		}
+
+		if c, ok := client.ClientInterface.(*sdk.ClientSDK); ok {
+			if c.Client, err = apiclient.CachedDoer(cmd, c.Client, apiIdentity()); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
 	var cmdFastedge = &cobra.Command{
 		Use:   "fastedge <subcommand>",
 		Short: "Gcore Edge compute solution",
 		Long:  ``,
 		Args:  cobra.MinimumNArgs(1),
 		PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
-			var err error
-			url := baseUrl
-			if !local {
-				url += "/fastedge"
-			}
-			client, err = sdk.NewClientWithVersionCheck(
-				url,
-				"gcore-cli",
-				"Gcore CLI tool",
-				sdk.WithRequestEditorFn(authFunc),
-			)
-			if err != nil {
-				return fmt.Errorf("cannot init SDK: %w", err)
+			if err := connect(cmd); err != nil {
+				return err
 			}
 
 			carbon.SetDefault(carbon.Default{
@@ -0,0 +1,274 @@
+package fastedge
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"maps"
+	"net/http"
+	"os"
+	"os/user"
+	"path/filepath"
+	"strconv"
+	"time"
+
+	sdk "github.com/G-Core/FastEdge-client-sdk-go"
+	"github.com/spf13/cobra"
+
+	"github.com/G-core/gcore-cli/internal/config"
+	e "github.com/G-core/gcore-cli/internal/errors"
+	"github.com/G-core/gcore-cli/internal/output"
+	"github.com/G-core/gcore-cli/internal/sure"
+)
+
+// deployment is a snapshot of app properties, recorded locally every time
+// the app is created or updated
+type deployment struct {
+	App        int64             `json:"app"`
+	Name       string            `json:"name"`
+	Binary     int64             `json:"binary"`
+	Env        map[string]string `json:"env,omitempty"`
+	RspHeaders map[string]string `json:"rsp_headers,omitempty"`
+	Time       time.Time         `json:"time"`
+	User       string            `json:"user,omitempty"`
+}
+
+func appHistory() *cobra.Command {
+	return &cobra.Command{
+		Use:   "history <app_name>",
+		Short: "Show app deployment history",
+		Long: `Show deployments of the app, made from this computer using "app create",
+"app update" and "app rollback" commands. History is kept locally, in the
+gcore-cli config directory.`,
+		Args:              cobra.ExactArgs(1),
+		ValidArgsFunction: completeArgs(completeApps),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			id, err := getAppIdByName(args[0])
+			if err != nil {
+				return fmt.Errorf("cannot find app by name: %w", err)
+			}
+
+			history, err := loadHistory(id)
+			if err != nil {
+				return err
+			}
+
+			if output.Format(cmd) == output.FmtJSON {
+				output.Print(history)
+				return nil
+			}
+
+			if len(history) == 0 {
+				fmt.Printf("no deployments recorded for app %d\n", id)
+				return nil
+			}
+
+			table := make([][]string, len(history)+1)
+			table[0] = []string{"N", "Time (UTC)", "User", "Binary", "Env vars", "Rsp headers"}
+			for i, d := range history {
+				table[i+1] = []string{
+					strconv.Itoa(i + 1),
+					d.Time.UTC().Format("2006-01-02T15:04:05"),
+					d.User,
+					strconv.FormatInt(d.Binary, 10),
+					strconv.Itoa(len(d.Env)),
+					strconv.Itoa(len(d.RspHeaders)),
+				}
+			}
+			output.Table(table, output.Format(cmd))
+			return nil
+		},
+	}
+}
+
+func appRollback() *cobra.Command {
+	var cmdRollback = &cobra.Command{
+		Use:   "rollback <app_name>",
+		Short: "Roll the app back to previous deployment",
+		Long: `Restore binary, environment and response headers of the app from the
+deployment history (see "app history"). By default, app is rolled back to
+the deployment preceding the latest one, use "--to N" to pick another one.`,
+		Args:              cobra.ExactArgs(1),
+		ValidArgsFunction: completeArgs(completeApps),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			id, err := getAppIdByName(args[0])
+			if err != nil {
+				return fmt.Errorf("cannot find app by name: %w", err)
+			}
+
+			history, err := loadHistory(id)
+			if err != nil {
+				return err
+			}
+
+			to, err := cmd.Flags().GetInt("to")
+			if err != nil {
+				return err
+			}
+			to, err = rollbackTarget(history, to)
+			if err != nil {
+				return &e.CliError{
+					Err:  fmt.Errorf("app %d: %w", id, err),
+					Hint: `Use "fastedge app history" to see recorded deployments`,
+					Code: 1,
+				}
+			}
+			target := history[to-1]
+
+			cur, err := getApp(id)
+			if err != nil {
+				return err
+			}
+
+			app := sdk.App{
+				Binary:     &target.Binary,
+				Env:        newPointer(replaceMap(derefMap(cur.Env), target.Env)),
+				RspHeaders: newPointer(replaceMap(derefMap(cur.RspHeaders), target.RspHeaders)),
+			}
+
+			if !sure.AreYou(cmd, fmt.Sprintf("roll app %d back to deployment #%d (binary %d)", id, to, target.Binary)) {
+				return e.ErrAborted
+			}
+
+			rsp, err := patchApp(id, app)
+			if err != nil {
+				return fmt.Errorf("rolling back the app: %w", err)
+			}
+			if rsp.StatusCode() != http.StatusOK {
+				return fmt.Errorf("rolling back the app: %s", extractErrorMessage(rsp.Body))
+			}
+			recordDeployment(id)
+
+			if output.Format(cmd) == output.FmtJSON {
+				fmt.Println(string(rsp.Body))
+				return nil
+			}
+
+			fmt.Printf("App %d rolled back to deployment #%d (binary %d)\n", id, to, target.Binary)
+			return nil
+		},
+	}
+	cmdRollback.Flags().Int("to", 0, "Deployment number from app history")
+
+	return cmdRollback
+}
+
+// rollbackTarget returns 1-based number of the deployment to roll back to,
+// defaulting to the one preceding the latest
+func rollbackTarget(history []deployment, to int) (int, error) {
+	if to == 0 {
+		if len(history) < 2 {
+			return 0, errors.New("nothing to roll back to, no previous deployment recorded")
+		}
+		return len(history) - 1, nil
+	}
+	if to < 1 || to > len(history) {
+		return 0, fmt.Errorf("no deployment #%d in history", to)
+	}
+	return to, nil
+}
+
+// replaceMap returns patch that turns cur key-value property into target,
+// as PATCH merges maps and deletes keys with empty values
+func replaceMap(cur, target map[string]string) map[string]string {
+	patch := maps.Clone(target)
+	if patch == nil {
+		patch = make(map[string]string)
+	}
+	for k := range cur {
+		if _, ok := target[k]; !ok {
+			patch[k] = ""
+		}
+	}
+	return patch
+}
+
+func derefMap(m *map[string]string) map[string]string {
+	if m == nil {
+		return nil
+	}
+	return *m
+}
+
+// recordDeployment saves current app state into deployment history.
+// Failure to record is not fatal for the command, only the warning is shown.
+func recordDeployment(id int64) {
+	if err := appendHistory(id); err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: cannot record app deployment: %v\n", err)
+	}
+}
+
+func appendHistory(id int64) error {
+	app, err := getApp(id)
+	if err != nil {
+		return err
+	}
+
+	d := deployment{
+		App:        id,
+		Name:       unrefString(app.Name),
+		Env:        derefMap(app.Env),
+		RspHeaders: derefMap(app.RspHeaders),
+		Time:       time.Now().UTC(),
+	}
+	if app.Binary != nil {
+		d.Binary = *app.Binary
+	}
+	if u, err := user.Current(); err == nil {
+		d.User = u.Username
+	}
+
+	history, err := loadHistory(id)
+	if err != nil {
+		return err
+	}
+	history = append(history, d)
+
+	if _, err := config.Dir("fastedge", "history"); err != nil {
+		return err
+	}
+	path, err := historyPath(id)
+	if err != nil {
+		return err
+	}
+	buf, err := json.MarshalIndent(history, "", "  ")
+	if err != nil {
+		return err
+	}
+	// history contains env values, which can be sensitive
+	return os.WriteFile(path, buf, 0600)
+}
+
+func loadHistory(id int64) ([]deployment, error) {
+	path, err := historyPath(id)
+	if err != nil {
+		return nil, err
+	}
+	buf, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("cannot read app history: %w", err)
+	}
+
+	var history []deployment
+	if err := json.Unmarshal(buf, &history); err != nil {
+		return nil, fmt.Errorf("cannot parse app history %s: %w", path, err)
+	}
+	return history, nil
+}
+
+// historyPath returns history file of the app. Apps of different accounts
+// or API endpoints can share the ID, so the file name includes the hash of
+// API URL and credentials, the same as used by the response cache.
+func historyPath(id int64) (string, error) {
+	dir, err := config.Path("fastedge", "history")
+	if err != nil {
+		return "", err
+	}
+	sum := sha256.Sum256([]byte(apiIdentity()))
+	return filepath.Join(dir, hex.EncodeToString(sum[:8])+"-"+strconv.FormatInt(id, 10)+".json"), nil
+}
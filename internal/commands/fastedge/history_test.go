@@ -0,0 +1,121 @@
+package fastedge
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/alecthomas/assert"
+)
+
+func TestReplaceMap(t *testing.T) {
+	type TestCase struct {
+		Cur      map[string]string
+		Target   map[string]string
+		Expected map[string]string
+	}
+
+	run := func(tc *TestCase) func(*testing.T) {
+		return func(t *testing.T) {
+			assert.Equal(t, tc.Expected, replaceMap(tc.Cur, tc.Target))
+		}
+	}
+
+	t.Run("both empty", run(&TestCase{
+		Cur:      nil,
+		Target:   nil,
+		Expected: map[string]string{},
+	}))
+	t.Run("keys added and changed", run(&TestCase{
+		Cur:      map[string]string{"A": "1"},
+		Target:   map[string]string{"A": "2", "B": "3"},
+		Expected: map[string]string{"A": "2", "B": "3"},
+	}))
+	t.Run("missing keys deleted", run(&TestCase{
+		Cur:      map[string]string{"A": "1", "B": "2"},
+		Target:   map[string]string{"A": "1"},
+		Expected: map[string]string{"A": "1", "B": ""},
+	}))
+	t.Run("all keys deleted", run(&TestCase{
+		Cur:      map[string]string{"A": "1"},
+		Target:   nil,
+		Expected: map[string]string{"A": ""},
+	}))
+}
+
+func TestRollbackTarget(t *testing.T) {
+	type TestCase struct {
+		Len      int
+		To       int
+		Expected int
+		Err      string
+	}
+
+	run := func(tc *TestCase) func(*testing.T) {
+		return func(t *testing.T) {
+			to, err := rollbackTarget(make([]deployment, tc.Len), tc.To)
+			if tc.Err != "" {
+				assert.EqualError(t, err, tc.Err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tc.Expected, to)
+		}
+	}
+
+	t.Run("empty history", run(&TestCase{
+		Len: 0,
+		Err: "nothing to roll back to, no previous deployment recorded",
+	}))
+	t.Run("single deployment", run(&TestCase{
+		Len: 1,
+		Err: "nothing to roll back to, no previous deployment recorded",
+	}))
+	t.Run("previous by default", run(&TestCase{
+		Len:      3,
+		Expected: 2,
+	}))
+	t.Run("explicit", run(&TestCase{
+		Len:      3,
+		To:       1,
+		Expected: 1,
+	}))
+	t.Run("latest", run(&TestCase{
+		Len:      3,
+		To:       3,
+		Expected: 3,
+	}))
+	t.Run("out of range", run(&TestCase{
+		Len: 3,
+		To:  4,
+		Err: "no deployment #4 in history",
+	}))
+	t.Run("negative", run(&TestCase{
+		Len: 3,
+		To:  -1,
+		Err: "no deployment #-1 in history",
+	}))
+}
+
+func TestHistoryPath(t *testing.T) {
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+	t.Setenv("HOME", t.TempDir())
+	defer func(f func() string) { apiIdentity = f }(apiIdentity)
+
+	apiIdentity = func() string { return "https://api.gcore.com\nAPIKey one" }
+	one, err := historyPath(1)
+	assert.NoError(t, err)
+	// reading history doesn't create the directory
+	_, err = os.Stat(filepath.Dir(one))
+	assert.True(t, errors.Is(err, os.ErrNotExist))
+
+	apiIdentity = func() string { return "https://api.gcore.com\nAPIKey two" }
+	two, err := historyPath(1)
+	assert.NoError(t, err)
+	assert.NotEqual(t, one, two)
+
+	other, err := historyPath(2)
+	assert.NoError(t, err)
+	assert.NotEqual(t, two, other)
+}
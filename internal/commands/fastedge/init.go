@@ -0,0 +1,177 @@
+package fastedge
+
+import (
+	"embed"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strings"
+	"text/template"
+
+	"github.com/spf13/cobra"
+)
+
+// templates contain app skeletons, files from "<lang>/common" are shared by
+// all templates of the language and "<lang>/<template>" adds the source code
+//
+//go:embed all:templates
+var templates embed.FS
+
+const templateCommon = "common"
+
+var appNameRe = regexp.MustCompile(`^[a-zA-Z][a-zA-Z0-9_-]*$`)
+
+// templateData is passed to the template files
+type templateData struct {
+	Name     string
+	Crate    string // Rust crate name, as used in the compiled file name
+	Template string
+}
+
+func appInit() *cobra.Command {
+	var cmdInit = &cobra.Command{
+		Use:   "init <new_app_name>",
+		Short: "Create new app project from the template",
+		Long: `Create new app project from the template. Project is created in the
+directory, named after the app, unless "--dir" flag is specified. Available
+templates:
+  hello    - responds with the greeting
+  proxy    - forwards requests to the origin, set in ORIGIN env variable
+  headers  - echoes request headers and adds a response header
+
+Project contains "fastedge.yaml" manifest and README with build instructions.`,
+		Args:        cobra.ExactArgs(1),
+		Annotations: map[string]string{"offline": "true"},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			name := args[0]
+			if !appNameRe.MatchString(name) {
+				return fmt.Errorf("invalid app name '%s': use letters, digits, '-' and '_'", name)
+			}
+			lang, err := cmd.Flags().GetString("lang")
+			if err != nil {
+				return err
+			}
+			tmpl, err := cmd.Flags().GetString("template")
+			if err != nil {
+				return err
+			}
+			dir, err := cmd.Flags().GetString("dir")
+			if err != nil {
+				return err
+			}
+			if dir == "" {
+				dir = name
+			}
+
+			if !slices.Contains(templateLangs(), lang) {
+				return fmt.Errorf("unsupported language '%s', use one of: %s", lang, strings.Join(templateLangs(), ", "))
+			}
+			if !slices.Contains(templateNames(lang), tmpl) {
+				return fmt.Errorf("unknown template '%s', use one of: %s", tmpl, strings.Join(templateNames(lang), ", "))
+			}
+
+			if entries, err := os.ReadDir(dir); err == nil && len(entries) > 0 {
+				return fmt.Errorf("directory %s already exists and is not empty", dir)
+			}
+
+			data := templateData{
+				Name:     name,
+				Crate:    strings.ReplaceAll(name, "-", "_"),
+				Template: tmpl,
+			}
+			files, err := renderTemplate(lang, tmpl, dir, data)
+			if err != nil {
+				return fmt.Errorf("creating the project: %w", err)
+			}
+
+			for _, f := range files {
+				fmt.Printf("created %s\n", f)
+			}
+			fmt.Printf("\nProject is ready, see %s for build and deploy instructions\n", filepath.Join(dir, "README.md"))
+			return nil
+		},
+	}
+	cmdInit.Flags().String("lang", "rust", "Project language, 'rust' or 'js'")
+	cmdInit.Flags().String("template", "hello", "Project template, 'hello', 'proxy' or 'headers'")
+	cmdInit.Flags().String("dir", "", "Project directory (default is the app name)")
+	cmdInit.MarkFlagDirname("dir")
+	cmdInit.RegisterFlagCompletionFunc("lang", cobra.FixedCompletions(templateLangs(), cobra.ShellCompDirectiveNoFileComp))
+	cmdInit.RegisterFlagCompletionFunc("template", func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		lang, _ := cmd.Flags().GetString("lang")
+		return templateNames(lang), cobra.ShellCompDirectiveNoFileComp
+	})
+
+	return cmdInit
+}
+
+func templateLangs() []string {
+	return templateDirs("templates")
+}
+
+func templateNames(lang string) []string {
+	return slices.DeleteFunc(templateDirs(path.Join("templates", lang)), func(s string) bool {
+		return s == templateCommon
+	})
+}
+
+func templateDirs(dir string) []string {
+	entries, err := templates.ReadDir(dir)
+	if err != nil {
+		return nil
+	}
+	var names []string
+	for _, entry := range entries {
+		if entry.IsDir() {
+			names = append(names, entry.Name())
+		}
+	}
+	return names
+}
+
+// renderTemplate writes common and template-specific files of the language
+// into dir, returning the list of created files
+func renderTemplate(lang, tmpl, dir string, data templateData) ([]string, error) {
+	var files []string
+	for _, src := range []string{path.Join("templates", lang, templateCommon), path.Join("templates", lang, tmpl)} {
+		err := fs.WalkDir(templates, src, func(name string, d fs.DirEntry, err error) error {
+			if err != nil || d.IsDir() {
+				return err
+			}
+			buf, err := templates.ReadFile(name)
+			if err != nil {
+				return err
+			}
+			t, err := template.New(name).Parse(string(buf))
+			if err != nil {
+				return err
+			}
+
+			dst := filepath.Join(dir, filepath.FromSlash(strings.TrimPrefix(name, src+"/")))
+			if err := os.MkdirAll(filepath.Dir(dst), 0755); err != nil {
+				return err
+			}
+			f, err := os.OpenFile(dst, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0644)
+			if err != nil {
+				return err
+			}
+			if err := t.Execute(f, data); err != nil {
+				f.Close()
+				return err
+			}
+			files = append(files, dst)
+			return f.Close()
+		})
+		if err != nil {
+			return files, err
+		}
+	}
+	if len(files) == 0 {
+		return nil, errors.New("template is empty")
+	}
+	return files, nil
+}
@@ -0,0 +1,26 @@
+package fastedge
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/alecthomas/assert"
+)
+
+func TestRenderTemplate(t *testing.T) {
+	for _, lang := range templateLangs() {
+		for _, tmpl := range templateNames(lang) {
+			t.Run(lang+"/"+tmpl, func(t *testing.T) {
+				dir := t.TempDir()
+				files, err := renderTemplate(lang, tmpl, dir, templateData{Name: "my-app", Crate: "my_app", Template: tmpl})
+				assert.NoError(t, err)
+				assert.Contains(t, files, filepath.Join(dir, "fastedge.yaml"))
+
+				manifest, err := os.ReadFile(filepath.Join(dir, "fastedge.yaml"))
+				assert.NoError(t, err)
+				assert.Contains(t, string(manifest), "name: my-app\n")
+			})
+		}
+	}
+}
@@ -0,0 +1,310 @@
+package fastedge
+
+import (
+	"fmt"
+	"maps"
+	"net/http"
+	"os"
+	"slices"
+	"strings"
+
+	sdk "github.com/G-Core/FastEdge-client-sdk-go"
+	"github.com/spf13/cobra"
+
+	"github.com/G-core/gcore-cli/internal/dotenv"
+	e "github.com/G-core/gcore-cli/internal/errors"
+	"github.com/G-core/gcore-cli/internal/output"
+	"github.com/G-core/gcore-cli/internal/sure"
+)
+
+// kvProperty describes key-value app property, such as env or response headers
+type kvProperty struct {
+	title  string // human-readable name, used in messages
+	get    func(app *sdk.App) *map[string]string
+	patch  func(m map[string]string) sdk.App
+	secret bool // values are masked in list output
+}
+
+var (
+	envProperty = kvProperty{
+		title:  "environment variable",
+		get:    func(app *sdk.App) *map[string]string { return app.Env },
+		patch:  func(m map[string]string) sdk.App { return sdk.App{Env: &m} },
+		secret: true,
+	}
+	headersProperty = kvProperty{
+		title: "response header",
+		get:   func(app *sdk.App) *map[string]string { return app.RspHeaders },
+		patch: func(m map[string]string) sdk.App { return sdk.App{RspHeaders: &m} },
+	}
+)
+
+// app environment commands
+func appEnv() *cobra.Command {
+	var cmdEnv = &cobra.Command{
+		Use:   "env <subcommand>",
+		Short: "Manage app environment variables",
+		Long: `Manage app environment variables. These commands change only app environment,
+leaving other app properties intact.`,
+		Args: cobra.MinimumNArgs(1),
+	}
+
+	cmdList := kvList(envProperty)
+	cmdList.Flags().Bool("show-secrets", false, "Show environment variable values")
+
+	var cmdImport = &cobra.Command{
+		Use:   "import <app_name> <filename>",
+		Short: "Import environment variables from dotenv file",
+		Long: `Set app environment variables from the file in dotenv format ('-' means stdin).
+Variables, not mentioned in the file, are left intact, unless "--replace"
+flag is specified.`,
+		Args:              cobra.ExactArgs(2),
+		ValidArgsFunction: completeArgs(completeApps, completeFiles),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			id, err := getAppIdByName(args[0])
+			if err != nil {
+				return fmt.Errorf("cannot find app by name: %w", err)
+			}
+
+			f := os.Stdin
+			if args[1] != sourceStdin {
+				f, err = os.Open(args[1])
+				if err != nil {
+					return fmt.Errorf("cannot open %s: %w", args[1], err)
+				}
+				defer f.Close()
+			}
+			env, err := dotenv.Parse(f)
+			if err != nil {
+				return fmt.Errorf("cannot parse %s: %w", args[1], err)
+			}
+
+			replace, err := cmd.Flags().GetBool("replace")
+			if err != nil {
+				return err
+			}
+			if replace {
+				app, err := getApp(id)
+				if err != nil {
+					return err
+				}
+				env = replaceMap(derefMap(app.Env), env)
+				if !sure.AreYou(cmd, fmt.Sprintf("replace environment of app %d", id)) {
+					return e.ErrAborted
+				}
+			}
+
+			return patchKV(cmd, id, envProperty, env,
+				fmt.Sprintf("Imported %d environment variables to app %d\n", len(env), id))
+		},
+	}
+	cmdImport.Flags().Bool("replace", false, "Remove variables not present in the file")
+
+	var cmdExport = &cobra.Command{
+		Use:               "export <app_name>",
+		Short:             "Export environment variables in dotenv format",
+		Args:              cobra.ExactArgs(1),
+		ValidArgsFunction: completeArgs(completeApps),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			id, err := getAppIdByName(args[0])
+			if err != nil {
+				return fmt.Errorf("cannot find app by name: %w", err)
+			}
+			app, err := getApp(id)
+			if err != nil {
+				return err
+			}
+
+			if output.Format(cmd) == output.FmtJSON {
+				output.Print(derefMap(app.Env))
+				return nil
+			}
+			return dotenv.Write(os.Stdout, derefMap(app.Env))
+		},
+	}
+
+	cmdEnv.AddCommand(
+		cmdList,
+		kvGet(envProperty),
+		kvSet(envProperty),
+		kvUnset(envProperty),
+		cmdImport,
+		cmdExport,
+	)
+	return cmdEnv
+}
+
+// app response headers commands
+func appHeaders() *cobra.Command {
+	var cmdHeaders = &cobra.Command{
+		Use:     "headers <subcommand>",
+		Aliases: []string{"rsp_headers"},
+		Short:   "Manage headers, added to app responses",
+		Long: `Manage headers, added to app responses. These commands change only app
+response headers, leaving other app properties intact.`,
+		Args: cobra.MinimumNArgs(1),
+	}
+
+	cmdHeaders.AddCommand(
+		kvList(headersProperty),
+		kvSet(headersProperty),
+		kvUnset(headersProperty),
+	)
+	return cmdHeaders
+}
+
+func kvList(prop kvProperty) *cobra.Command {
+	return &cobra.Command{
+		Use:               "list <app_name>",
+		Aliases:           []string{"ls"},
+		Short:             fmt.Sprintf("Show app %ss", prop.title),
+		Args:              cobra.ExactArgs(1),
+		ValidArgsFunction: completeArgs(completeApps),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			id, err := getAppIdByName(args[0])
+			if err != nil {
+				return fmt.Errorf("cannot find app by name: %w", err)
+			}
+			app, err := getApp(id)
+			if err != nil {
+				return err
+			}
+			m := prop.get(app)
+
+			if output.Format(cmd) == output.FmtJSON {
+				output.Print(derefMap(m))
+				return nil
+			}
+
+			if len(derefMap(m)) == 0 {
+				fmt.Printf("app %d has no %ss\n", id, prop.title)
+				return nil
+			}
+
+			if prop.secret {
+				showSecrets, err := cmd.Flags().GetBool("show-secrets")
+				if err != nil {
+					return err
+				}
+				if !showSecrets {
+					m = maskValues(m)
+				}
+			}
+
+			keys := slices.Sorted(maps.Keys(*m))
+			table := make([][]string, len(keys)+1)
+			table[0] = []string{"Name", "Value"}
+			for i, k := range keys {
+				table[i+1] = []string{k, (*m)[k]}
+			}
+			output.Table(table, output.Format(cmd))
+			return nil
+		},
+	}
+}
+
+func kvGet(prop kvProperty) *cobra.Command {
+	return &cobra.Command{
+		Use:               "get <app_name> <name>",
+		Short:             fmt.Sprintf("Show app %s value", prop.title),
+		Args:              cobra.ExactArgs(2),
+		ValidArgsFunction: completeArgs(completeApps),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			id, err := getAppIdByName(args[0])
+			if err != nil {
+				return fmt.Errorf("cannot find app by name: %w", err)
+			}
+			app, err := getApp(id)
+			if err != nil {
+				return err
+			}
+
+			val, ok := derefMap(prop.get(app))[args[1]]
+			if !ok {
+				return fmt.Errorf("app %d has no %s %q", id, prop.title, args[1])
+			}
+
+			if output.Format(cmd) == output.FmtJSON {
+				output.Print(map[string]string{args[1]: val})
+				return nil
+			}
+			fmt.Println(val)
+			return nil
+		},
+	}
+}
+
+func kvSet(prop kvProperty) *cobra.Command {
+	return &cobra.Command{
+		Use:               "set <app_name> <name=value>...",
+		Short:             fmt.Sprintf("Add or change app %ss", prop.title),
+		Args:              cobra.MinimumNArgs(2),
+		ValidArgsFunction: completeArgs(completeApps),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			id, err := getAppIdByName(args[0])
+			if err != nil {
+				return fmt.Errorf("cannot find app by name: %w", err)
+			}
+
+			m := make(map[string]string, len(args)-1)
+			for _, arg := range args[1:] {
+				k, v, ok := strings.Cut(arg, "=")
+				if !ok || k == "" {
+					return fmt.Errorf("malformed %s %q, expected name=value", prop.title, arg)
+				}
+				if v == "" {
+					return fmt.Errorf(`empty value for %s %q, use "unset" to remove it`, prop.title, k)
+				}
+				m[k] = v
+			}
+
+			return patchKV(cmd, id, prop, m,
+				fmt.Sprintf("Set %d %ss of app %d\n", len(m), prop.title, id))
+		},
+	}
+}
+
+func kvUnset(prop kvProperty) *cobra.Command {
+	return &cobra.Command{
+		Use:               "unset <app_name> <name>...",
+		Aliases:           []string{"rm"},
+		Short:             fmt.Sprintf("Remove app %ss", prop.title),
+		Args:              cobra.MinimumNArgs(2),
+		ValidArgsFunction: completeArgs(completeApps),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			id, err := getAppIdByName(args[0])
+			if err != nil {
+				return fmt.Errorf("cannot find app by name: %w", err)
+			}
+
+			// empty value deletes the key
+			m := make(map[string]string, len(args)-1)
+			for _, k := range args[1:] {
+				m[k] = ""
+			}
+
+			return patchKV(cmd, id, prop, m,
+				fmt.Sprintf("Removed %d %ss of app %d\n", len(m), prop.title, id))
+		},
+	}
+}
+
+// patchKV sends the key-value property patch and prints the result
+func patchKV(cmd *cobra.Command, id int64, prop kvProperty, m map[string]string, msg string) error {
+	rsp, err := patchApp(id, prop.patch(m))
+	if err != nil {
+		return fmt.Errorf("updating the app: %w", err)
+	}
+	if rsp.StatusCode() != http.StatusOK {
+		return fmt.Errorf("updating the app: %s", extractErrorMessage(rsp.Body))
+	}
+	recordDeployment(id)
+
+	if output.Format(cmd) == output.FmtJSON {
+		fmt.Println(string(rsp.Body))
+		return nil
+	}
+
+	fmt.Print(msg)
+	return nil
+}
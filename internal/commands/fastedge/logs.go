@@ -23,6 +23,11 @@ func appLogsFilterFlags(cmd *cobra.Command) {
 	cmd.Flags().String("edge", "", "Edge name filter")
 	cmd.Flags().String("client-ip", "", "Client IP filter")
 	cmd.Flags().MarkHidden("client-ip")
+	cmd.RegisterFlagCompletionFunc("sort", cobra.FixedCompletions(
+		[]string{string(sdk.ListLogsParamsSortAsc), string(sdk.ListLogsParamsSortDesc)},
+		cobra.ShellCompDirectiveNoFileComp,
+	))
+	cmd.RegisterFlagCompletionFunc("edge", completeEdges)
 }
 
 // logs-related commands
@@ -45,7 +50,8 @@ func logs() *cobra.Command {
 		Short: "Show app logs",
 		Long: `Show app logs printed to stdout/stderr. 
 This command allows you filtering by edge name, client ip and time range.`,
-		Args: cobra.ExactArgs(1),
+		Args:              cobra.ExactArgs(1),
+		ValidArgsFunction: completeArgs(completeApps),
 		PreRunE: func(cmd *cobra.Command, args []string) error {
 
 			sortFlag, err := cmd.Flags().GetString("sort")
@@ -174,19 +180,16 @@ This command allows you filtering by edge name, client ip and time range.`,
 	}
 
 	var cmdLogEnable = &cobra.Command{
-		Use:   "enable <app_name>",
-		Short: "Enable app logging",
-		Args:  cobra.ExactArgs(1),
+		Use:               "enable <app_name>",
+		Short:             "Enable app logging",
+		Args:              cobra.ExactArgs(1),
+		ValidArgsFunction: completeArgs(completeApps),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			id, err := getAppIdByName(args[0])
 			if err != nil {
 				return fmt.Errorf("cannot find app by name: %w", err)
 			}
-			rsp, err := client.PatchAppWithResponse(
-				context.Background(),
-				id,
-				sdk.App{Debug: newPointer(true)},
-			)
+			rsp, err := patchApp(id, sdk.App{Debug: newPointer(true)})
 			if err != nil {
 				return fmt.Errorf("enabling logging: %w", err)
 			}
@@ -215,19 +218,16 @@ This command allows you filtering by edge name, client ip and time range.`,
 	}
 
 	var cmdLogDisable = &cobra.Command{
-		Use:   "disable <app_name>",
-		Short: "Disable app logging",
-		Args:  cobra.ExactArgs(1),
+		Use:               "disable <app_name>",
+		Short:             "Disable app logging",
+		Args:              cobra.ExactArgs(1),
+		ValidArgsFunction: completeArgs(completeApps),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			id, err := getAppIdByName(args[0])
 			if err != nil {
 				return fmt.Errorf("cannot find app by name: %w", err)
 			}
-			rsp, err := client.PatchAppWithResponse(
-				context.Background(),
-				id,
-				sdk.App{Debug: newPointer(false)},
-			)
+			rsp, err := patchApp(id, sdk.App{Debug: newPointer(false)})
 			if err != nil {
 				return fmt.Errorf("disabling logging: %w", err)
 			}
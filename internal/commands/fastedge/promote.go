@@ -0,0 +1,215 @@
+package fastedge
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"slices"
+	"strconv"
+	"time"
+
+	sdk "github.com/G-Core/FastEdge-client-sdk-go"
+	"github.com/spf13/cobra"
+
+	e "github.com/G-core/gcore-cli/internal/errors"
+	"github.com/G-core/gcore-cli/internal/output"
+	"github.com/G-core/gcore-cli/internal/sure"
+)
+
+func appPromote() *cobra.Command {
+	var cmdPromote = &cobra.Command{
+		Use:   "promote <canary_app_name> <prod_app_name>",
+		Short: "Copy binary, env and response headers from one app to another",
+		Long: `Promote canary app to production: binary, environment and response headers
+of the production app are replaced with the ones of the canary app. The
+difference is shown before asking for confirmation, values of environment
+variables are masked, unless "--show-secrets" is specified.
+With "--max-5xx" flag, share of 5xx responses of the canary app over the
+"--window" period (1 hour by default) is checked first, and promotion is
+refused if it exceeds the specified percentage.`,
+		Args:              cobra.ExactArgs(2),
+		ValidArgsFunction: completeArgs(completeApps, completeApps),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			srcId, err := getAppIdByName(args[0])
+			if err != nil {
+				return fmt.Errorf("cannot find app by name: %w", err)
+			}
+			dstId, err := getAppIdByName(args[1])
+			if err != nil {
+				return fmt.Errorf("cannot find app by name: %w", err)
+			}
+
+			maxRate, err := cmd.Flags().GetFloat64("max-5xx")
+			if err != nil {
+				return err
+			}
+			if maxRate > 0 {
+				window, err := cmd.Flags().GetDuration("window")
+				if err != nil {
+					return err
+				}
+				if err := check5xxRate(srcId, window, maxRate); err != nil {
+					return err
+				}
+			}
+
+			src, err := getApp(srcId)
+			if err != nil {
+				return err
+			}
+			dst, err := getApp(dstId)
+			if err != nil {
+				return err
+			}
+
+			showSecrets, err := cmd.Flags().GetBool("show-secrets")
+			if err != nil {
+				return err
+			}
+			diff := appDiff(dst, src, showSecrets)
+			if len(diff) == 0 {
+				fmt.Printf("App %d is already the same as app %d\n", dstId, srcId)
+				return nil
+			}
+			if output.Format(cmd) != output.FmtJSON {
+				fmt.Printf("Changes to app %d:\n", dstId)
+				for _, line := range diff {
+					fmt.Println("\t" + line)
+				}
+			}
+
+			if !sure.AreYou(cmd, fmt.Sprintf("promote app %d to app %d", srcId, dstId)) {
+				return e.ErrAborted
+			}
+
+			rsp, err := patchApp(dstId, sdk.App{
+				Binary:     src.Binary,
+				Env:        newPointer(replaceMap(derefMap(dst.Env), derefMap(src.Env))),
+				RspHeaders: newPointer(replaceMap(derefMap(dst.RspHeaders), derefMap(src.RspHeaders))),
+			})
+			if err != nil {
+				return fmt.Errorf("promoting the app: %w", err)
+			}
+			if rsp.StatusCode() != http.StatusOK {
+				return fmt.Errorf("promoting the app: %s", extractErrorMessage(rsp.Body))
+			}
+			recordDeployment(dstId)
+
+			if output.Format(cmd) == output.FmtJSON {
+				fmt.Println(string(rsp.Body))
+				return nil
+			}
+
+			fmt.Printf("App %d promoted to app %d\n", srcId, dstId)
+			return nil
+		},
+	}
+	cmdPromote.Flags().Float64("max-5xx", 0, "Maximum allowed share of canary 5xx responses, percent (0 - don't check)")
+	cmdPromote.Flags().Duration("window", time.Hour, "Period to check canary 5xx responses for")
+	cmdPromote.Flags().Bool("show-secrets", false, "Show environment variable values in the difference")
+
+	return cmdPromote
+}
+
+// check5xxRate returns an error if the share of app 5xx responses for the
+// last window exceeds maxRate percent
+func check5xxRate(id int64, window time.Duration, maxRate float64) error {
+	to := time.Now().UTC()
+	rsp, err := client.StatsCallsWithResponse(
+		context.Background(),
+		&sdk.StatsCallsParams{
+			Id:   &id,
+			From: to.Add(-window),
+			To:   to,
+			Step: int(window.Seconds()),
+		},
+	)
+	if err != nil {
+		return fmt.Errorf("cannot get statistics: %w", err)
+	}
+	if rsp.StatusCode() != http.StatusOK {
+		return fmt.Errorf("cannot get statistics: %s", extractErrorMessage(rsp.Body))
+	}
+
+	var total, failed int
+	for _, slot := range rsp.JSON200.Stats {
+		for _, count := range slot.CountByStatus {
+			total += count.Count
+			if count.Status >= 500 && count.Status < 600 {
+				failed += count.Count
+			}
+		}
+	}
+	if total == 0 {
+		return &e.CliError{
+			Err:  fmt.Errorf("app %d had no calls for the last %s", id, window),
+			Hint: `Increase checking period with "--window" flag or skip the check`,
+			Code: 1,
+		}
+	}
+
+	rate := 100 * float64(failed) / float64(total)
+	if rate > maxRate {
+		return &e.CliError{
+			Err: fmt.Errorf("app %d 5xx rate %.2f%% exceeds allowed %.2f%%", id, rate, maxRate),
+			Details: fmt.Sprintf("%d of %d calls for the last %s returned 5xx status",
+				failed, total, window),
+			Code: 1,
+		}
+	}
+	return nil
+}
+
+// appDiff describes changes of binary, env and response headers, required
+// to turn app "from" into app "to". Env values are shown only if showSecrets
+// is set.
+func appDiff(from, to *sdk.App, showSecrets bool) []string {
+	var diff []string
+	fromBin, toBin := int64(0), int64(0)
+	if from.Binary != nil {
+		fromBin = *from.Binary
+	}
+	if to.Binary != nil {
+		toBin = *to.Binary
+	}
+	if fromBin != toBin {
+		diff = append(diff, fmt.Sprintf("binary: %d -> %d", fromBin, toBin))
+	}
+	diff = append(diff, mapDiff("env", derefMap(from.Env), derefMap(to.Env), !showSecrets)...)
+	diff = append(diff, mapDiff("rsp_headers", derefMap(from.RspHeaders), derefMap(to.RspHeaders), false)...)
+	return diff
+}
+
+// mapDiff describes changed keys of the map, with masked values only the
+// kind of change is shown
+func mapDiff(title string, from, to map[string]string, masked bool) []string {
+	keys := make([]string, 0, len(from)+len(to))
+	for k := range from {
+		keys = append(keys, k)
+	}
+	for k := range to {
+		if _, ok := from[k]; !ok {
+			keys = append(keys, k)
+		}
+	}
+	slices.Sort(keys)
+
+	var diff []string
+	for _, k := range keys {
+		oldVal, hadOld := from[k]
+		newVal, hasNew := to[k]
+		switch {
+		case !hasNew:
+			diff = append(diff, fmt.Sprintf("%s %s: removed", title, k))
+		case !hadOld && masked:
+			diff = append(diff, fmt.Sprintf("%s %s: added", title, k))
+		case !hadOld:
+			diff = append(diff, fmt.Sprintf("%s %s: added %s", title, k, strconv.Quote(newVal)))
+		case oldVal != newVal && masked:
+			diff = append(diff, fmt.Sprintf("%s %s: changed", title, k))
+		case oldVal != newVal:
+			diff = append(diff, fmt.Sprintf("%s %s: %s -> %s", title, k, strconv.Quote(oldVal), strconv.Quote(newVal)))
+		}
+	}
+	return diff
+}
@@ -0,0 +1,39 @@
+package fastedge
+
+import (
+	"testing"
+
+	sdk "github.com/G-Core/FastEdge-client-sdk-go"
+	"github.com/alecthomas/assert"
+)
+
+func TestAppDiff(t *testing.T) {
+	from := &sdk.App{
+		Binary:     newPointer(int64(1)),
+		Env:        &map[string]string{"TOKEN": "old", "GONE": "x", "SAME": "s"},
+		RspHeaders: &map[string]string{"X-Ver": "1"},
+	}
+	to := &sdk.App{
+		Binary:     newPointer(int64(2)),
+		Env:        &map[string]string{"TOKEN": "new", "NEW": "secret", "SAME": "s"},
+		RspHeaders: &map[string]string{"X-Ver": "2"},
+	}
+
+	assert.Equal(t, []string{
+		"binary: 1 -> 2",
+		"env GONE: removed",
+		"env NEW: added",
+		"env TOKEN: changed",
+		`rsp_headers X-Ver: "1" -> "2"`,
+	}, appDiff(from, to, false))
+
+	assert.Equal(t, []string{
+		"binary: 1 -> 2",
+		"env GONE: removed",
+		`env NEW: added "secret"`,
+		`env TOKEN: "old" -> "new"`,
+		`rsp_headers X-Ver: "1" -> "2"`,
+	}, appDiff(from, to, true))
+
+	assert.Equal(t, 0, len(appDiff(from, from, false)))
+}
@@ -65,7 +65,8 @@ but you can change reporting interval using "--from" and "--to" flags
 (specifying date/time in format "YYYY-MM-DD HH:mm:SS", where either date or time,
 can be omitted, or as UNIX timestamp) and reporting step duration with flag
 "--step" (in seconds).`,
-		Args: cobra.MaximumNArgs(1),
+		Args:              cobra.MaximumNArgs(1),
+		ValidArgsFunction: completeArgs(completeApps),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			var appId *int64
 			if len(args) > 0 {
@@ -181,7 +182,8 @@ but you can change reporting interval using "--from" and "--to" flags
 (specifying date/time in format "YYYY-MM-DD HH:mm:SS", where either date or time,
 can be omitted, or as UNIX timestamp) and reporting step duration with flag
 "--step" (in seconds).`,
-		Args: cobra.MaximumNArgs(1),
+		Args:              cobra.MaximumNArgs(1),
+		ValidArgsFunction: completeArgs(completeApps),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			var appId *int64
 			if len(args) > 0 {
@@ -0,0 +1,17 @@
+// Returns request headers in the response body and adds custom response header
+async function eventHandler(event) {
+  let body = '';
+  for (const [name, value] of event.request.headers) {
+    body += `${name}: ${value}\n`;
+  }
+  return new Response(body, {
+    headers: {
+      'Content-Type': 'text/plain',
+      'X-Powered-By': '{{.Name}}',
+    },
+  });
+}
+
+addEventListener('fetch', (event) => {
+  event.respondWith(eventHandler(event));
+});
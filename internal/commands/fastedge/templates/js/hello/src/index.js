@@ -0,0 +1,9 @@
+async function eventHandler(event) {
+  return new Response('Hello from {{.Name}}!\n', {
+    headers: { 'Content-Type': 'text/plain' },
+  });
+}
+
+addEventListener('fetch', (event) => {
+  event.respondWith(eventHandler(event));
+});
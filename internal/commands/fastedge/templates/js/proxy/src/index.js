@@ -0,0 +1,26 @@
+import { getEnv } from 'fastedge::env';
+
+// Forwards requests to the origin, set in ORIGIN environment variable
+async function eventHandler(event) {
+  const origin = getEnv('ORIGIN');
+  if (!origin) {
+    return new Response('ORIGIN is not set\n', { status: 500 });
+  }
+
+  const url = new URL(event.request.url);
+  const headers = new Headers(event.request.headers);
+  headers.delete('host');
+  try {
+    return await fetch(origin.replace(/\/$/, '') + url.pathname + url.search, {
+      method: event.request.method,
+      headers,
+      body: event.request.body,
+    });
+  } catch (err) {
+    return new Response(`origin request failed: ${err}\n`, { status: 502 });
+  }
+}
+
+addEventListener('fetch', (event) => {
+  event.respondWith(eventHandler(event));
+});
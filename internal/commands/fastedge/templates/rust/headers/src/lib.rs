@@ -0,0 +1,17 @@
+use fastedge::body::Body;
+use fastedge::http::{Error, Request, Response, StatusCode};
+
+// Returns request headers in the response body and adds custom response header
+#[fastedge::http]
+fn main(req: Request<Body>) -> Result<Response<Body>, Error> {
+    let mut body = String::new();
+    for (name, value) in req.headers() {
+        body.push_str(&format!("{}: {}\n", name, value.to_str().unwrap_or("<binary>")));
+    }
+
+    Response::builder()
+        .status(StatusCode::OK)
+        .header("Content-Type", "text/plain")
+        .header("X-Powered-By", "{{.Name}}")
+        .body(Body::from(body))
+}
@@ -0,0 +1,10 @@
+use fastedge::body::Body;
+use fastedge::http::{Error, Request, Response, StatusCode};
+
+#[fastedge::http]
+fn main(_req: Request<Body>) -> Result<Response<Body>, Error> {
+    Response::builder()
+        .status(StatusCode::OK)
+        .header("Content-Type", "text/plain")
+        .body(Body::from("Hello from {{.Name}}!\n"))
+}
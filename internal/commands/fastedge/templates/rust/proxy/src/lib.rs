@@ -0,0 +1,32 @@
+use std::env;
+
+use fastedge::body::Body;
+use fastedge::http::{Error, Request, Response, StatusCode};
+
+// Forwards requests to the origin, set in ORIGIN environment variable
+#[fastedge::http]
+fn main(req: Request<Body>) -> Result<Response<Body>, Error> {
+    let Ok(origin) = env::var("ORIGIN") else {
+        return Response::builder()
+            .status(StatusCode::INTERNAL_SERVER_ERROR)
+            .body(Body::from("ORIGIN is not set\n"));
+    };
+
+    let path = req.uri().path_and_query().map(|p| p.as_str()).unwrap_or("/");
+    let mut upstream = Request::builder()
+        .method(req.method())
+        .uri(format!("{}{}", origin.trim_end_matches('/'), path));
+    for (name, value) in req.headers() {
+        if name != "host" {
+            upstream = upstream.header(name, value);
+        }
+    }
+    let upstream = upstream.body(req.into_body())?;
+
+    match fastedge::send_request(upstream) {
+        Ok(rsp) => Ok(rsp),
+        Err(err) => Response::builder()
+            .status(StatusCode::BAD_GATEWAY)
+            .body(Body::from(format!("origin request failed: {}\n", err))),
+    }
+}
@@ -0,0 +1,154 @@
+package fastedge
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+
+	sdk "github.com/G-Core/FastEdge-client-sdk-go"
+	"github.com/spf13/cobra"
+
+	e "github.com/G-core/gcore-cli/internal/errors"
+	"github.com/G-core/gcore-cli/internal/httpcache"
+	"github.com/G-core/gcore-cli/internal/poll"
+	"github.com/G-core/gcore-cli/internal/progress"
+)
+
+const (
+	binStatusPending = 0
+	binStatusOK      = 1
+	appStatusDraft   = 0
+	appStatusEnabled = 1
+	appStatusOff     = 2
+)
+
+// waitFor calls poll with context limited by "--wait-timeout", if "--wait" is
+// specified. Timeout starts only here, so uploads and confirmation prompts
+// before polling don't count.
+func waitFor(cmd *cobra.Command, poll func(ctx context.Context) error) error {
+	wait, err := cmd.Flags().GetBool("wait")
+	if err != nil || !wait {
+		return err
+	}
+	timeout, err := cmd.Flags().GetDuration("wait-timeout")
+	if err != nil {
+		return err
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+	return poll(ctx)
+}
+
+// waitForBinary polls the binary until it is compiled, returning an error
+// with compiler output if compilation failed
+func waitForBinary(ctx context.Context, id int64) error {
+	spinner := progress.StartSpinner(progress.Out(), fmt.Sprintf("Compiling binary %d", id))
+	defer spinner.Stop()
+
+	var poller poll.Poller
+	for {
+		rsp, err := client.GetBinaryWithResponse(httpcache.Bypass(ctx), id)
+		if err != nil {
+			return poll.Err(ctx, fmt.Errorf("getting binary status: %w", err))
+		}
+		if rsp.StatusCode() != http.StatusOK {
+			return fmt.Errorf("getting binary status: %s", extractErrorMessage(rsp.Body))
+		}
+
+		switch rsp.JSON200.Status {
+		case binStatusPending:
+		case binStatusOK:
+			return nil
+		default:
+			return &e.CliError{
+				Err:     fmt.Errorf("binary %d: %s", id, binStatusToString(rsp.JSON200.Status)),
+				Details: extractCompilerErrors(rsp.Body),
+				Code:    1,
+			}
+		}
+
+		if err := poller.Wait(ctx); err != nil {
+			return err
+		}
+	}
+}
+
+// waitForApp polls the app until it leaves draft state. Disabled app is an
+// error only if it must be enabled.
+func waitForApp(ctx context.Context, id int64, mustEnable bool) error {
+	spinner := progress.StartSpinner(progress.Out(), fmt.Sprintf("Deploying app %d", id))
+	defer spinner.Stop()
+
+	var poller poll.Poller
+	for {
+		rsp, err := client.GetAppWithResponse(httpcache.Bypass(ctx), id)
+		if err != nil {
+			return poll.Err(ctx, fmt.Errorf("getting app status: %w", err))
+		}
+		if rsp.StatusCode() != http.StatusOK {
+			return fmt.Errorf("getting app status: %s", extractErrorMessage(rsp.Body))
+		}
+
+		status := appStatusDraft
+		if rsp.JSON200.Status != nil {
+			status = *rsp.JSON200.Status
+		}
+		switch status {
+		case appStatusDraft:
+		case appStatusEnabled:
+			return nil
+		case appStatusOff:
+			if !mustEnable {
+				return nil
+			}
+			return &e.CliError{
+				Err:  fmt.Errorf("app %d is disabled", id),
+				Hint: `You can enable it with "--disabled=false" flag`,
+				Code: 1,
+			}
+		default:
+			return fmt.Errorf("app %d is not live: %s", id, appStatusToString(status))
+		}
+
+		if err := poller.Wait(ctx); err != nil {
+			return err
+		}
+	}
+}
+
+// extractCompilerErrors gets compiler output from binary details. This field
+// is not described by SDK schema yet, so it is parsed from raw response.
+func extractCompilerErrors(body []byte) string {
+	var rsp struct {
+		Errors json.RawMessage `json:"errors"`
+	}
+	if err := json.Unmarshal(body, &rsp); err != nil || len(rsp.Errors) == 0 {
+		return ""
+	}
+	var list []string
+	if err := json.Unmarshal(rsp.Errors, &list); err == nil {
+		return strings.Join(list, "\n")
+	}
+	var msg string
+	if err := json.Unmarshal(rsp.Errors, &msg); err == nil {
+		return msg
+	}
+	return string(rsp.Errors)
+}
+
+// waitForDeploy waits for the binary compilation and then for the app to be
+// deployed. The app must go live only if it is being enabled, app with
+// unchanged status may stay disabled.
+func waitForDeploy(ctx context.Context, app sdk.App, id int64) error {
+	if app.Binary != nil {
+		if err := waitForBinary(ctx, *app.Binary); err != nil {
+			return err
+		}
+	}
+	if app.Status != nil && *app.Status == appStatusOff {
+		return nil
+	}
+	return waitForApp(ctx, id, app.Status != nil && *app.Status == appStatusEnabled)
+}
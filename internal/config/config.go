@@ -0,0 +1,67 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+
+	"github.com/spf13/viper"
+)
+
+const (
+	appDir     = "gcore-cli"
+	configFile = "config.yaml"
+)
+
+// Dir returns the path to CLI local data directory (or its subdirectory),
+// creating it if necessary
+func Dir(sub ...string) (string, error) {
+	dir, err := Path(sub...)
+	if err != nil {
+		return "", err
+	}
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return "", fmt.Errorf("cannot create %s: %w", dir, err)
+	}
+	return dir, nil
+}
+
+// File returns the path to CLI config file. Config values are defaults for
+// some global flags of the same name, like "project". The file and its
+// directory may not exist.
+func File() (string, error) {
+	return Path(configFile)
+}
+
+// Set stores the value in CLI config file, keeping other values
+func Set(key string, value any) error {
+	if _, err := Dir(); err != nil {
+		return err
+	}
+	path, err := File()
+	if err != nil {
+		return err
+	}
+	v := viper.New()
+	v.SetConfigFile(path)
+	if err := v.ReadInConfig(); err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return fmt.Errorf("cannot read %s: %w", path, err)
+	}
+	v.Set(key, value)
+	if err := v.WriteConfigAs(path); err != nil {
+		return fmt.Errorf("cannot write %s: %w", path, err)
+	}
+	return os.Chmod(path, 0600)
+}
+
+// Path returns the path inside CLI local data directory without creating it,
+// for reading files which may not exist
+func Path(sub ...string) (string, error) {
+	base, err := os.UserConfigDir()
+	if err != nil {
+		return "", fmt.Errorf("cannot find config directory: %w", err)
+	}
+	return filepath.Join(append([]string{base, appDir}, sub...)...), nil
+}
@@ -2,16 +2,22 @@ package core
 
 import (
 	"context"
+	stderrors "errors"
 	"fmt"
 	"net/http"
 	"os"
+	"slices"
 	"strings"
+	"time"
 
 	"github.com/spf13/cobra"
 	"github.com/spf13/pflag"
 	"github.com/spf13/viper"
 
+	"github.com/G-core/gcore-cli/internal/commands/cdn"
+	"github.com/G-core/gcore-cli/internal/commands/cloud"
 	"github.com/G-core/gcore-cli/internal/commands/fastedge"
+	"github.com/G-core/gcore-cli/internal/config"
 	"github.com/G-core/gcore-cli/internal/errors"
 	"github.com/G-core/gcore-cli/internal/human"
 	"github.com/G-core/gcore-cli/internal/output"
@@ -29,9 +35,12 @@ func Execute() {
 	apiKey := rootCmd.PersistentFlags().StringP("apikey", "a", "", "API key")
 	apiUrl := rootCmd.PersistentFlags().StringP("url", "u", "https://api.gcore.com", "API URL")
 	rootCmd.PersistentFlags().BoolP("force", "f", false, `Assume answer "yes" to all "are you sure?" questions`)
-	rootCmd.PersistentFlags().IntP("project", "", 0, "Cloud project ID")
-	rootCmd.PersistentFlags().IntP("region", "", 0, "Cloud region ID")
+	rootCmd.PersistentFlags().StringP("project", "", "", "Cloud project ID or name")
+	rootCmd.PersistentFlags().StringP("region", "", "", "Cloud region ID or name")
 	rootCmd.PersistentFlags().BoolP("wait", "", false, "Wait for command result")
+	rootCmd.PersistentFlags().DurationP("wait-timeout", "", 5*time.Minute, "Maximum time to wait for command result")
+	rootCmd.PersistentFlags().DurationP("cache-ttl", "", 0, "Cache read-only API responses locally for this time (0 - don't cache)")
+	rootCmd.PersistentFlags().BoolP("no-cache", "", false, "Don't use locally cached API responses")
 	output.FormatOption(rootCmd)
 	rootCmd.ParseFlags(os.Args[1:])
 
@@ -39,7 +48,13 @@ func Execute() {
 	v.SetEnvPrefix("gcore")
 	v.SetEnvKeyReplacer(strings.NewReplacer("-", "_"))
 	v.AutomaticEnv()
-	bindFlags(rootCmd, v)
+	// config file values are used when neither flag nor env variable is set
+	cfg := viper.New()
+	if path, err := config.File(); err == nil {
+		cfg.SetConfigFile(path)
+		cfg.ReadInConfig()
+	}
+	bindFlags(rootCmd, v, cfg)
 
 	authFunc := func(ctx context.Context, req *http.Request) error {
 		req.Header.Set("Authorization", "APIKey "+*apiKey)
@@ -47,11 +62,15 @@ func Execute() {
 	}
 
 	rootCmd.PersistentPreRunE = func(cmd *cobra.Command, args []string) error {
-		for _, safeCmd := range []string{"completion", "help"} {
+		for _, safeCmd := range []string{"completion", "help", cobra.ShellCompRequestCmd} {
 			if strings.Contains(cmd.CommandPath(), safeCmd) {
 				return nil
 			}
 		}
+		// commands, working with local files only, don't need credentials
+		if cmd.Annotations["offline"] == "true" {
+			return nil
+		}
 		if *apiUrl == "" {
 			return &errors.CliError{
 				Message: "URL for API isn't specified",
@@ -88,12 +107,24 @@ func Execute() {
 		os.Exit(1)
 	}
 
-	rootCmd.AddCommand(fastedgeCmd)
+	cloudCmd, err := cloud.Commands(*apiUrl, authFunc)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Failed: %v\n", err)
+		os.Exit(1)
+	}
+
+	cdnCmd, err := cdn.Commands(*apiUrl, authFunc)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Failed: %v\n", err)
+		os.Exit(1)
+	}
+
+	rootCmd.AddCommand(fastedgeCmd, cloudCmd, cdnCmd)
 	cobra.EnableTraverseRunHooks = true // make sure all parentPersistentPreRun executed
 	err = rootCmd.Execute()
 	if err != nil {
-		cliErr, ok := err.(*errors.CliError)
-		if !ok {
+		var cliErr *errors.CliError
+		if !stderrors.As(err, &cliErr) {
 			fmt.Fprintf(os.Stderr, "Failed: %v\n", err)
 			os.Exit(1)
 		}
@@ -104,12 +135,21 @@ func Execute() {
 	}
 }
 
-func bindFlags(cmd *cobra.Command, v *viper.Viper) {
+// configFlags are global flags, which defaults can be stored in config file.
+// Others, like "force", must not be turned on silently for every command.
+var configFlags = []string{"project", "region", "url", "cache-ttl"}
+
+func bindFlags(cmd *cobra.Command, env, cfg *viper.Viper) {
 	cmd.PersistentFlags().VisitAll(func(f *pflag.Flag) {
-		// Apply the viper config value to the flag when the flag is not set and viper has a value
-		if !f.Changed && v.IsSet(f.Name) {
-			val := v.Get(f.Name)
-			cmd.Flags().Set(f.Name, fmt.Sprintf("%v", val))
+		if f.Changed {
+			return
+		}
+		// Apply the env or config value to the flag when the flag is not set
+		switch {
+		case env.IsSet(f.Name):
+			cmd.Flags().Set(f.Name, fmt.Sprintf("%v", env.Get(f.Name)))
+		case slices.Contains(configFlags, f.Name) && cfg.IsSet(f.Name):
+			cmd.Flags().Set(f.Name, fmt.Sprintf("%v", cfg.Get(f.Name)))
 		}
 	})
 }
@@ -0,0 +1,135 @@
+package dotenv
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+)
+
+// Parse reads variables in dotenv format: "NAME=value" lines, optionally
+// prefixed with "export". Values can be single-quoted (taken literally) or
+// double-quoted (supporting \n, \t, \", \\ escapes), quoted values can span
+// multiple lines. Lines starting with "#" and unquoted text after " #" are
+// comments.
+func Parse(r io.Reader) (map[string]string, error) {
+	ret := make(map[string]string)
+	scanner := bufio.NewScanner(r)
+	lineNo := 0
+	for scanner.Scan() {
+		lineNo++
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		line = strings.TrimPrefix(line, "export ")
+
+		name, value, ok := strings.Cut(line, "=")
+		name = strings.TrimSpace(name)
+		if !ok || name == "" || strings.ContainsAny(name, " \t") {
+			return nil, fmt.Errorf("line %d: expected NAME=value", lineNo)
+		}
+		value = strings.TrimLeft(value, " \t")
+
+		if value == "" || (value[0] != '"' && value[0] != '\'') {
+			if i := strings.Index(value, " #"); i >= 0 {
+				value = value[:i]
+			}
+			ret[name] = strings.TrimSpace(value)
+			continue
+		}
+
+		// quoted value, read until closing quote, possibly on the next lines
+		quote := value[0]
+		startLine := lineNo
+		text := value[1:]
+		for {
+			if end := closingQuote(text, quote); end >= 0 {
+				rest := strings.TrimSpace(text[end+1:])
+				if rest != "" && !strings.HasPrefix(rest, "#") {
+					return nil, fmt.Errorf("line %d: unexpected text after closing quote", lineNo)
+				}
+				text = text[:end]
+				break
+			}
+			if !scanner.Scan() {
+				return nil, fmt.Errorf("line %d: unterminated quoted value", startLine)
+			}
+			lineNo++
+			text += "\n" + scanner.Text()
+		}
+		if quote == '"' {
+			text = unescape(text)
+		}
+		ret[name] = text
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	return ret, nil
+}
+
+// closingQuote returns index of unescaped closing quote in s, or -1
+func closingQuote(s string, quote byte) int {
+	for i := 0; i < len(s); i++ {
+		switch {
+		case s[i] == '\\' && quote == '"':
+			i++
+		case s[i] == quote:
+			return i
+		}
+	}
+	return -1
+}
+
+func unescape(s string) string {
+	var b strings.Builder
+	for i := 0; i < len(s); i++ {
+		if s[i] != '\\' || i == len(s)-1 {
+			b.WriteByte(s[i])
+			continue
+		}
+		i++
+		switch s[i] {
+		case 'n':
+			b.WriteByte('\n')
+		case 't':
+			b.WriteByte('\t')
+		case 'r':
+			b.WriteByte('\r')
+		case '"', '\\':
+			b.WriteByte(s[i])
+		default:
+			b.WriteByte('\\')
+			b.WriteByte(s[i])
+		}
+	}
+	return b.String()
+}
+
+// Write outputs variables in dotenv format, sorted by name. Values are
+// double-quoted when necessary, so the output can be read back with Parse.
+func Write(w io.Writer, vars map[string]string) error {
+	names := make([]string, 0, len(vars))
+	for name := range vars {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+
+	for _, name := range names {
+		if _, err := fmt.Fprintf(w, "%s=%s\n", name, quote(vars[name])); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func quote(s string) string {
+	if s != "" && !strings.ContainsAny(s, " \t\r\n\"'#\\") {
+		return s
+	}
+	r := strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`, "\t", `\t`, "\r", `\r`)
+	return `"` + r.Replace(s) + `"`
+}
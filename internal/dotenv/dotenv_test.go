@@ -0,0 +1,81 @@
+package dotenv
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/alecthomas/assert"
+)
+
+func TestParse(t *testing.T) {
+	type TestCase struct {
+		Input    string
+		Expected map[string]string
+		Err      string
+	}
+
+	run := func(tc *TestCase) func(*testing.T) {
+		return func(t *testing.T) {
+			actual, err := Parse(strings.NewReader(tc.Input))
+			if tc.Err != "" {
+				assert.Error(t, err)
+				assert.Equal(t, tc.Err, err.Error())
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tc.Expected, actual)
+		}
+	}
+
+	t.Run("simple", run(&TestCase{
+		Input:    "A=1\n\n# comment\nexport B = two words # trailing comment\nC=",
+		Expected: map[string]string{"A": "1", "B": "two words", "C": ""},
+	}))
+	t.Run("single quoted", run(&TestCase{
+		Input:    `A='x\ny # not a comment'`,
+		Expected: map[string]string{"A": `x\ny # not a comment`},
+	}))
+	t.Run("double quoted", run(&TestCase{
+		Input:    `A="say \"hi\"\tnow\n" # comment`,
+		Expected: map[string]string{"A": "say \"hi\"\tnow\n"},
+	}))
+	t.Run("multiline", run(&TestCase{
+		Input:    "KEY=\"-----BEGIN-----\nabc\n-----END-----\"\nB=2",
+		Expected: map[string]string{"KEY": "-----BEGIN-----\nabc\n-----END-----", "B": "2"},
+	}))
+	t.Run("unterminated", run(&TestCase{
+		Input: "A=1\nB='abc\ndef",
+		Err:   "line 2: unterminated quoted value",
+	}))
+	t.Run("malformed", run(&TestCase{
+		Input: "A=1\njust text",
+		Err:   "line 2: expected NAME=value",
+	}))
+	t.Run("text after quote", run(&TestCase{
+		Input: `A="abc" def`,
+		Err:   "line 1: unexpected text after closing quote",
+	}))
+}
+
+func TestWrite(t *testing.T) {
+	vars := map[string]string{
+		"PLAIN":  "value",
+		"EMPTY":  "",
+		"SPACES": "two words # not a comment",
+		"QUOTES": `it's "quoted" \o/`,
+		"LINES":  "line1\nline2\ttab",
+	}
+
+	var buf strings.Builder
+	assert.NoError(t, Write(&buf, vars))
+	assert.Equal(t, `EMPTY=""
+LINES="line1\nline2\ttab"
+PLAIN=value
+QUOTES="it's \"quoted\" \\o/"
+SPACES="two words # not a comment"
+`, buf.String())
+
+	parsed, err := Parse(strings.NewReader(buf.String()))
+	assert.NoError(t, err)
+	assert.Equal(t, vars, parsed)
+}
@@ -3,6 +3,8 @@ package errors
 import (
 	"encoding/json"
 	"errors"
+	"slices"
+	"strings"
 )
 
 var ErrAborted = errors.New("operation aborted")
@@ -22,3 +24,39 @@ func ParseCloudErr(body []byte) *CliError {
 		Code: 1,
 	}
 }
+
+// ParseCdnErr converts CDN API error response, either with the message or
+// with the list of errors per field, like {"errors":{"cname":["required"]}}
+func ParseCdnErr(body []byte) *CliError {
+	s := struct {
+		Message string              `json:"message"`
+		Detail  string              `json:"detail"`
+		Errors  map[string][]string `json:"errors"`
+	}{}
+
+	if err := json.Unmarshal(body, &s); err != nil {
+		return nil
+	}
+
+	cliErr := &CliError{Code: 1}
+	var fields []string
+	for field, msgs := range s.Errors {
+		fields = append(fields, field+": "+strings.Join(msgs, ", "))
+	}
+	slices.Sort(fields)
+	switch {
+	case s.Message != "":
+		cliErr.Err = errors.New(s.Message)
+	case s.Detail != "":
+		cliErr.Err = errors.New(s.Detail)
+	case len(fields) == 1:
+		cliErr.Err = errors.New(fields[0])
+		return cliErr
+	case len(fields) > 1:
+		cliErr.Err = errors.New("request is invalid")
+	default:
+		return nil
+	}
+	cliErr.Details = strings.Join(fields, "\n")
+	return cliErr
+}
@@ -0,0 +1,20 @@
+package flagutil
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+)
+
+// NonNegativeInt returns the value of integer flag, like "--limit" or
+// "--offset", rejecting negative values
+func NonNegativeInt(cmd *cobra.Command, name string) (int, error) {
+	val, err := cmd.Flags().GetInt(name)
+	if err != nil {
+		return 0, err
+	}
+	if val < 0 {
+		return 0, fmt.Errorf("invalid argument \"%d\" for \"--%s\" flag: must not be negative", val, name)
+	}
+	return val, nil
+}
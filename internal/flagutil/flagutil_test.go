@@ -0,0 +1,22 @@
+package flagutil
+
+import (
+	"testing"
+
+	"github.com/alecthomas/assert"
+	"github.com/spf13/cobra"
+)
+
+func TestNonNegativeInt(t *testing.T) {
+	cmd := &cobra.Command{}
+	cmd.Flags().Int("offset", 0, "")
+
+	assert.NoError(t, cmd.ParseFlags([]string{"--offset", "2"}))
+	val, err := NonNegativeInt(cmd, "offset")
+	assert.NoError(t, err)
+	assert.Equal(t, 2, val)
+
+	assert.NoError(t, cmd.ParseFlags([]string{"--offset", "-1"}))
+	_, err = NonNegativeInt(cmd, "offset")
+	assert.EqualError(t, err, `invalid argument "-1" for "--offset" flag: must not be negative`)
+}
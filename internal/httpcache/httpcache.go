@@ -0,0 +1,116 @@
+package httpcache
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// Doer performs HTTP requests, it is implemented by *http.Client
+type Doer interface {
+	Do(req *http.Request) (*http.Response, error)
+}
+
+// Client caches successful GET responses on disk for TTL. Any other request
+// (POST, PUT, PATCH, DELETE) drops all cached responses, as it may change
+// the data. Responses are cached per identity, so different accounts or API
+// endpoints don't share the cache.
+type Client struct {
+	Doer     Doer
+	Dir      string
+	TTL      time.Duration // 0 disables caching, only invalidation is done
+	Identity string
+	Refresh  bool // don't use cached responses, but still cache new ones
+}
+
+type bypassKey struct{}
+
+// Bypass returns context for requests that must always reach the server,
+// such as status polling, or must not be stored on disk, such as credentials.
+// Responses to such requests are neither read from nor written to the cache.
+func Bypass(ctx context.Context) context.Context {
+	return context.WithValue(ctx, bypassKey{}, true)
+}
+
+type entry struct {
+	Header http.Header `json:"header"`
+	Body   []byte      `json:"body"`
+}
+
+func (c *Client) Do(req *http.Request) (*http.Response, error) {
+	if req.Method != http.MethodGet {
+		rsp, err := c.Doer.Do(req)
+		if err == nil && rsp.StatusCode < http.StatusBadRequest {
+			c.Invalidate()
+		}
+		return rsp, err
+	}
+
+	if bypass, _ := req.Context().Value(bypassKey{}).(bool); bypass || c.TTL <= 0 {
+		return c.Doer.Do(req)
+	}
+
+	path := c.path(req.URL.String())
+	if fi, err := os.Stat(path); err == nil && !c.Refresh && time.Since(fi.ModTime()) < c.TTL {
+		if buf, err := os.ReadFile(path); err == nil {
+			var e entry
+			if json.Unmarshal(buf, &e) == nil {
+				return &http.Response{
+					Status:        "200 OK",
+					StatusCode:    http.StatusOK,
+					Proto:         "HTTP/1.1",
+					ProtoMajor:    1,
+					ProtoMinor:    1,
+					Header:        e.Header,
+					Body:          io.NopCloser(bytes.NewReader(e.Body)),
+					ContentLength: int64(len(e.Body)),
+					Request:       req,
+				}, nil
+			}
+		}
+	}
+
+	rsp, err := c.Doer.Do(req)
+	if err != nil || rsp.StatusCode != http.StatusOK {
+		return rsp, err
+	}
+	body, err := io.ReadAll(rsp.Body)
+	rsp.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	rsp.Body = io.NopCloser(bytes.NewReader(body))
+
+	// failure to cache is not an error, the response is just not cached
+	if buf, err := json.Marshal(entry{Header: rsp.Header, Body: body}); err == nil {
+		if os.MkdirAll(filepath.Dir(path), 0700) == nil {
+			os.WriteFile(path, buf, 0600)
+		}
+	}
+	return rsp, nil
+}
+
+// Invalidate drops all cached responses for the client identity
+func (c *Client) Invalidate() {
+	os.RemoveAll(c.identityDir())
+}
+
+func (c *Client) identityDir() string {
+	return filepath.Join(c.Dir, hash(c.Identity))
+}
+
+func (c *Client) path(url string) string {
+	return filepath.Join(c.identityDir(), hash(url)+".json")
+}
+
+func hash(s string) string {
+	sum := sha256.Sum256([]byte(s))
+	return hex.EncodeToString(sum[:16])
+}
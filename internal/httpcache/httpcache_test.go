@@ -0,0 +1,81 @@
+package httpcache
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/alecthomas/assert"
+)
+
+func TestClient(t *testing.T) {
+	hits := 0
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		hits++
+		w.Header().Set("Content-Type", "application/json")
+		io.WriteString(w, `{"path":"`+r.URL.Path+`"}`)
+	}))
+	defer srv.Close()
+
+	c := &Client{
+		Doer:     http.DefaultClient,
+		Dir:      t.TempDir(),
+		TTL:      time.Minute,
+		Identity: "test",
+	}
+
+	get := func(path string) string {
+		req, err := http.NewRequest(http.MethodGet, srv.URL+path, nil)
+		assert.NoError(t, err)
+		rsp, err := c.Do(req)
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusOK, rsp.StatusCode)
+		assert.Equal(t, "application/json", rsp.Header.Get("Content-Type"))
+		body, err := io.ReadAll(rsp.Body)
+		assert.NoError(t, err)
+		return string(body)
+	}
+
+	assert.Equal(t, `{"path":"/a"}`, get("/a"))
+	assert.Equal(t, `{"path":"/a"}`, get("/a"))
+	assert.Equal(t, 1, hits)
+
+	assert.Equal(t, `{"path":"/b"}`, get("/b"))
+	assert.Equal(t, 2, hits)
+
+	// mutating request drops the cache
+	req, err := http.NewRequest(http.MethodPatch, srv.URL+"/a", nil)
+	assert.NoError(t, err)
+	_, err = c.Do(req)
+	assert.NoError(t, err)
+	assert.Equal(t, 3, hits)
+
+	assert.Equal(t, `{"path":"/a"}`, get("/a"))
+	assert.Equal(t, 4, hits)
+
+	// bypass and refresh skip reading the cache, bypass doesn't store the response
+	req, err = http.NewRequestWithContext(Bypass(context.Background()), http.MethodGet, srv.URL+"/a", nil)
+	assert.NoError(t, err)
+	_, err = c.Do(req)
+	assert.NoError(t, err)
+	assert.Equal(t, 5, hits)
+	c.Refresh = true
+	get("/a")
+	assert.Equal(t, 6, hits)
+	c.Refresh = false
+
+	req, err = http.NewRequestWithContext(Bypass(context.Background()), http.MethodGet, srv.URL+"/c", nil)
+	assert.NoError(t, err)
+	_, err = c.Do(req)
+	assert.NoError(t, err)
+	get("/c")
+	assert.Equal(t, 8, hits)
+
+	// other identity doesn't share the cache
+	c.Identity = "other"
+	get("/a")
+	assert.Equal(t, 9, hits)
+}
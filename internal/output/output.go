@@ -48,6 +48,10 @@ func (f *outputFormat) Type() string {
 
 func FormatOption(cmd *cobra.Command) {
 	cmd.PersistentFlags().VarP(&globalFormat, outputOption, "o", `Output format ("json", "csv" or "human', default "human")`)
+	cmd.RegisterFlagCompletionFunc(outputOption, cobra.FixedCompletions(
+		[]string{string(FmtHuman), string(FmtJSON), string(FmtCSV)},
+		cobra.ShellCompDirectiveNoFileComp,
+	))
 }
 
 func Format(cmd *cobra.Command) outputFormat {
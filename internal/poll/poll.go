@@ -0,0 +1,49 @@
+package poll
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	e "github.com/G-core/gcore-cli/internal/errors"
+)
+
+// polling interval grows from Interval up to MaxInterval
+const (
+	Interval    = time.Second
+	MaxInterval = 10 * time.Second
+	backoff     = 1.5
+)
+
+// Poller paces repeated status requests, waiting longer between each
+type Poller struct {
+	interval time.Duration
+}
+
+// Wait sleeps until the next poll. When the context is done, the error is
+// converted with Err.
+func (p *Poller) Wait(ctx context.Context) error {
+	if p.interval == 0 {
+		p.interval = Interval
+	}
+	select {
+	case <-ctx.Done():
+		return Err(ctx, ctx.Err())
+	case <-time.After(p.interval):
+	}
+	p.interval = min(time.Duration(float64(p.interval)*backoff), MaxInterval)
+	return nil
+}
+
+// Err replaces err with the timeout error, pointing to "--wait-timeout"
+// flag, if the context deadline is exceeded
+func Err(ctx context.Context, err error) error {
+	if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+		return &e.CliError{
+			Err:  errors.New("timed out waiting for the result"),
+			Hint: `You can increase waiting time with "--wait-timeout" flag`,
+			Code: 1,
+		}
+	}
+	return err
+}
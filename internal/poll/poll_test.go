@@ -0,0 +1,34 @@
+package poll
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/alecthomas/assert"
+
+	e "github.com/G-core/gcore-cli/internal/errors"
+)
+
+func TestPollerCancel(t *testing.T) {
+	var p Poller
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	// cancelled context returns immediately, without changing the interval
+	assert.Equal(t, context.Canceled, p.Wait(ctx))
+	assert.Equal(t, Interval, p.interval)
+}
+
+func TestErr(t *testing.T) {
+	other := errors.New("other")
+
+	ctx, cancel := context.WithTimeout(context.Background(), 0)
+	defer cancel()
+	<-ctx.Done()
+	var cliErr *e.CliError
+	assert.True(t, errors.As(Err(ctx, other), &cliErr))
+	assert.Equal(t, "timed out waiting for the result", cliErr.Err.Error())
+
+	assert.Equal(t, other, Err(context.Background(), other))
+}
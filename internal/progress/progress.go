@@ -0,0 +1,99 @@
+package progress
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/dustin/go-humanize"
+
+	"github.com/G-core/gcore-cli/internal/output"
+	"github.com/G-core/gcore-cli/internal/terminal"
+)
+
+// minimal interval between progress line redraws
+const refreshInterval = 200 * time.Millisecond
+
+// Out returns where to draw progress indicators, or nil if they shouldn't be
+// drawn at all (non-interactive session or JSON output)
+func Out() io.Writer {
+	if output.IsJSON() || !terminal.IsStderrTerm() {
+		return nil
+	}
+	return os.Stderr
+}
+
+// Reader wraps an io.Reader and draws transfer progress (bytes sent, rate
+// and ETA) on the given writer as the data is consumed.
+type Reader struct {
+	r     io.Reader
+	out   io.Writer
+	total int64 // total size in bytes, 0 or less if unknown
+	done  int64
+	start time.Time
+	drawn time.Time
+	width int // length of the last drawn line, to wipe leftovers
+}
+
+// NewReader returns reader reporting progress to out. If total is unknown,
+// pass 0, then only transferred bytes and rate are shown.
+func NewReader(r io.Reader, total int64, out io.Writer) *Reader {
+	return &Reader{
+		r:     r,
+		out:   out,
+		total: total,
+		start: time.Now(),
+	}
+}
+
+func (p *Reader) Read(buf []byte) (int, error) {
+	n, err := p.r.Read(buf)
+	p.done += int64(n)
+	if time.Since(p.drawn) >= refreshInterval {
+		p.draw()
+	}
+	return n, err
+}
+
+// Finish draws the final state and moves the cursor to the next line
+func (p *Reader) Finish() {
+	p.draw()
+	fmt.Fprintln(p.out)
+}
+
+func (p *Reader) draw() {
+	p.drawn = time.Now()
+	elapsed := p.drawn.Sub(p.start).Seconds()
+	var rate float64
+	if elapsed > 0 {
+		rate = float64(p.done) / elapsed
+	}
+
+	var line string
+	if p.total > 0 {
+		line = fmt.Sprintf("Uploading: %s / %s (%.0f%%), %s/s",
+			humanize.IBytes(uint64(p.done)),
+			humanize.IBytes(uint64(p.total)),
+			100*float64(p.done)/float64(p.total),
+			humanize.IBytes(uint64(rate)),
+		)
+		if rate > 0 && p.done < p.total {
+			eta := time.Duration(float64(p.total-p.done) / rate * float64(time.Second))
+			line += ", ETA " + eta.Round(time.Second).String()
+		}
+	} else {
+		line = fmt.Sprintf("Uploading: %s, %s/s",
+			humanize.IBytes(uint64(p.done)),
+			humanize.IBytes(uint64(rate)),
+		)
+	}
+
+	pad := ""
+	if len(line) < p.width {
+		pad = strings.Repeat(" ", p.width-len(line))
+	}
+	p.width = len(line)
+	fmt.Fprint(p.out, "\r"+line+pad)
+}
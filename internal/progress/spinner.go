@@ -0,0 +1,78 @@
+package progress
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+	"time"
+)
+
+var spinnerFrames = []string{"|", "/", "-", "\\"}
+
+// Spinner draws an animated activity indicator with a message while some
+// long operation is in progress. Spinner with nil writer draws nothing.
+type Spinner struct {
+	out  io.Writer
+	mu   sync.Mutex
+	msg  string
+	stop chan struct{}
+	done chan struct{}
+}
+
+// StartSpinner starts drawing the spinner with the message on out
+func StartSpinner(out io.Writer, msg string) *Spinner {
+	s := &Spinner{
+		out:  out,
+		msg:  msg,
+		stop: make(chan struct{}),
+		done: make(chan struct{}),
+	}
+	if out == nil {
+		close(s.done)
+		return s
+	}
+
+	go func() {
+		defer close(s.done)
+		ticker := time.NewTicker(refreshInterval)
+		defer ticker.Stop()
+		width := 0
+		for i := 0; ; i++ {
+			s.mu.Lock()
+			line := spinnerFrames[i%len(spinnerFrames)] + " " + s.msg
+			s.mu.Unlock()
+			pad := ""
+			if len(line) < width {
+				pad = strings.Repeat(" ", width-len(line))
+			}
+			width = len(line)
+			fmt.Fprint(s.out, "\r"+line+pad)
+
+			select {
+			case <-s.stop:
+				fmt.Fprint(s.out, "\r"+strings.Repeat(" ", width)+"\r")
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+	return s
+}
+
+// Update changes the message shown next to the spinner
+func (s *Spinner) Update(msg string) {
+	s.mu.Lock()
+	s.msg = msg
+	s.mu.Unlock()
+}
+
+// Stop wipes the spinner line
+func (s *Spinner) Stop() {
+	select {
+	case <-s.stop:
+	default:
+		close(s.stop)
+	}
+	<-s.done
+}
@@ -0,0 +1,59 @@
+package suggest
+
+import (
+	"slices"
+	"strings"
+)
+
+// Closest returns up to max candidates, most similar to s, ordered by edit
+// distance. Candidates that are too different from s are not returned.
+func Closest(s string, candidates []string, max int) []string {
+	type match struct {
+		name string
+		dist int
+	}
+
+	// allow about a third of the name to differ
+	limit := len(s)/3 + 1
+	var matches []match
+	for _, c := range candidates {
+		d := Distance(strings.ToLower(s), strings.ToLower(c))
+		if d <= limit {
+			matches = append(matches, match{name: c, dist: d})
+		}
+	}
+	slices.SortStableFunc(matches, func(a, b match) int {
+		return a.dist - b.dist
+	})
+
+	ret := make([]string, 0, max)
+	for _, m := range matches {
+		if len(ret) == max {
+			break
+		}
+		ret = append(ret, m.name)
+	}
+	return ret
+}
+
+// Distance returns Levenshtein edit distance between two strings
+func Distance(a, b string) int {
+	ra, rb := []rune(a), []rune(b)
+	prev := make([]int, len(rb)+1)
+	cur := make([]int, len(rb)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(ra); i++ {
+		cur[0] = i
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+			cur[j] = min(prev[j]+1, cur[j-1]+1, prev[j-1]+cost)
+		}
+		prev, cur = cur, prev
+	}
+	return prev[len(rb)]
+}
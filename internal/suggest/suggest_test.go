@@ -0,0 +1,24 @@
+package suggest
+
+import (
+	"testing"
+
+	"github.com/alecthomas/assert"
+)
+
+func TestDistance(t *testing.T) {
+	assert.Equal(t, 0, Distance("", ""))
+	assert.Equal(t, 3, Distance("abc", ""))
+	assert.Equal(t, 1, Distance("my-app", "my-apps"))
+	assert.Equal(t, 1, Distance("my-app", "my_app"))
+	assert.Equal(t, 3, Distance("kitten", "sitting"))
+}
+
+func TestClosest(t *testing.T) {
+	candidates := []string{"hello-world", "proxy", "hello-word", "Hello-World-2", "headers"}
+
+	assert.Equal(t, []string{"hello-world", "hello-word", "Hello-World-2"}, Closest("hello-wrld", candidates, 3))
+	assert.Equal(t, []string{"hello-world"}, Closest("hello-wrld", candidates, 1))
+	assert.Equal(t, []string{"proxy"}, Closest("prxy", candidates, 3))
+	assert.Equal(t, []string{}, Closest("something", candidates, 3))
+}
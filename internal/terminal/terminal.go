@@ -34,3 +34,8 @@ func GetHeight() int {
 func IsTerm() bool {
 	return !color.NoColor
 }
+
+// IsStderrTerm returns if stderr is attached to a tty
+func IsStderrTerm() bool {
+	return term.IsTerminal(int(os.Stderr.Fd()))
+}